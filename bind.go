@@ -0,0 +1,264 @@
+// Package validator
+package validator
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const (
+	tagBindPath       = `path`
+	tagBindQuery      = `query`
+	tagBindForm       = `form`
+	tagBindHeader     = `header`
+	tagBindTimeFormat = `time_format`
+)
+
+// defaultMaxMemory is the size of a multipart form held in memory, the files
+// beyond are stored on disk, as net/http does
+const defaultMaxMemory = 32 << 20
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// Source holds the raw request values used to populate a struct
+type Source struct {
+	Path   map[string]string
+	Query  url.Values
+	Form   url.Values
+	Header http.Header
+}
+
+// lookup find the values of a field, the first tag having a value wins
+// in this order: path, query, form, header
+func (s Source) lookup(tag reflect.StructTag) ([]string, bool) {
+	if name := tag.Get(tagBindPath); name != "" && s.Path != nil {
+		if val, ok := s.Path[name]; ok {
+			return []string{val}, true
+		}
+	}
+
+	if name := tag.Get(tagBindQuery); name != "" {
+		if vals, ok := s.Query[name]; ok {
+			return vals, true
+		}
+	}
+
+	if name := tag.Get(tagBindForm); name != "" {
+		if vals, ok := s.Form[name]; ok {
+			return vals, true
+		}
+	}
+
+	if name := tag.Get(tagBindHeader); name != "" && s.Header != nil {
+		if vals := s.Header.Values(name); len(vals) > 0 {
+			return vals, true
+		}
+	}
+
+	return nil, false
+}
+
+// Bind populate the struct pointed by input from the source values using
+// the path, query, form and header tags, then validate it.
+// Conversion failures are reported in the same error bag as the rules
+func (vl *Validator) Bind(input interface{}, src Source) url.Values {
+	errBag := url.Values{}
+
+	val := reflect.ValueOf(input)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		errBag.Set(`_error`, `validator: bind requires a non-nil pointer to struct`)
+		return errBag
+	}
+
+	bindStruct(vl, newWalker(vl), val.Elem(), "", src, errBag)
+
+	return mergeKeys(errBag, vl.ValidateStruct(input))
+}
+
+// BindRequest bind the query, form and header values of an http request along
+// with the path parameters extracted by the router, then validate the struct.
+// The form is read from an url encoded or a multipart body
+func (vl *Validator) BindRequest(r *http.Request, path map[string]string, input interface{}) url.Values {
+	form, err := requestForm(r)
+	if err != nil {
+		errBag := url.Values{}
+		errBag.Set(`_error`, fmt.Sprintf("validator: %s", err.Error()))
+		return errBag
+	}
+
+	return vl.Bind(input, Source{
+		Path:   path,
+		Query:  r.URL.Query(),
+		Form:   form,
+		Header: r.Header,
+	})
+}
+
+// requestForm parse the body of the request and return its form values,
+// a multipart body is parsed with its files beyond defaultMaxMemory on disk
+func requestForm(r *http.Request) (url.Values, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		return r.PostForm, nil
+	}
+
+	if err := r.ParseMultipartForm(defaultMaxMemory); err != nil {
+		return nil, err
+	}
+
+	return url.Values(r.MultipartForm.Value), nil
+}
+
+// bindStruct populate the fields of the struct from the source values and
+// return whether the source had a value for one of them. A nil pointer to a
+// nested struct is allocated when the source has a value for one of its fields
+func bindStruct(vl *Validator, w *walker, v reflect.Value, parentField string, src Source, errBag url.Values) bool {
+	if !w.enter(v, parentField, errBag) {
+		return false
+	}
+	defer w.leave(v)
+
+	bound := false
+
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		fi := t.Field(i)
+		fv := v.Field(i)
+
+		// unexported field can not be set
+		if fi.PkgPath != "" {
+			continue
+		}
+
+		tf := vl.fieldKey(parentField, fi)
+		if isPromoted(fi, vl.TagField) {
+			tf = parentField
+		}
+
+		vals, ok := src.lookup(fi.Tag)
+		if !ok {
+			switch {
+			case fv.Kind() == reflect.Struct && fv.Type() != timeType:
+				bound = bindStruct(vl, w, fv, tf, src, errBag) || bound
+			case fv.Kind() == reflect.Ptr && !fv.IsNil() && fv.Elem().Kind() == reflect.Struct:
+				bound = bindStruct(vl, w, fv.Elem(), tf, src, errBag) || bound
+			case fv.Kind() == reflect.Ptr && fv.IsNil() && fv.Type().Elem().Kind() == reflect.Struct &&
+				fv.Type().Elem() != timeType && !w.walking(fv.Type().Elem()):
+				// a recursive type is not allocated, its fields share the tags of its parent
+				ptr := reflect.New(fv.Type().Elem())
+				if bindStruct(vl, w, ptr.Elem(), tf, src, errBag) {
+					fv.Set(ptr)
+					bound = true
+				}
+			}
+			continue
+		}
+
+		bound = true
+
+		if err := setValue(fv, vals, fi.Tag.Get(tagBindTimeFormat)); err != nil {
+			errBag.Add(tf, fmt.Sprintf(`The %s field should be a valid %s`, tf, err.Error()))
+		}
+	}
+
+	return bound
+}
+
+// setValue convert the raw values into the field type, slices receive every
+// value while the other types only use the first one.
+// The returned error holds the name of the expected type
+func setValue(v reflect.Value, vals []string, layout string) error {
+	if len(vals) == 0 {
+		return nil
+	}
+
+	switch {
+	case v.Kind() == reflect.Ptr:
+		ptr := reflect.New(v.Type().Elem())
+		if err := setValue(ptr.Elem(), vals, layout); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8:
+		sl := reflect.MakeSlice(v.Type(), len(vals), len(vals))
+		for i, s := range vals {
+			if err := setValue(sl.Index(i), []string{s}, layout); err != nil {
+				return err
+			}
+		}
+		v.Set(sl)
+		return nil
+	}
+
+	return setScalar(v, vals[0], layout)
+}
+
+func setScalar(v reflect.Value, s, layout string) error {
+	switch v.Type() {
+	case timeType:
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		tm, err := time.Parse(layout, s)
+		if err != nil {
+			return fmt.Errorf("time")
+		}
+		v.Set(reflect.ValueOf(tm))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("duration")
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Slice:
+		// []byte
+		v.SetBytes([]byte(s))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("boolean")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("integer")
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("unsigned integer")
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("float number")
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("%s", v.Type().String())
+	}
+
+	return nil
+}
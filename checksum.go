@@ -0,0 +1,179 @@
+// Package validator
+package validator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// cardBrand describes the prefixes and lengths of a credit card brand
+type cardBrand struct {
+	prefixes [][2]int // inclusive ranges of the leading digits
+	lengths  []int
+}
+
+// cardBrands are the brands accepted by the credit_card rule parameters
+var cardBrands = map[string]cardBrand{
+	"visa":       {prefixes: [][2]int{{4, 4}}, lengths: []int{13, 16, 19}},
+	"mastercard": {prefixes: [][2]int{{51, 55}, {2221, 2720}}, lengths: []int{16}},
+	"amex":       {prefixes: [][2]int{{34, 34}, {37, 37}}, lengths: []int{15}},
+	"diners":     {prefixes: [][2]int{{300, 305}, {36, 36}, {38, 39}}, lengths: []int{14, 15, 16, 17, 18, 19}},
+	"discover":   {prefixes: [][2]int{{6011, 6011}, {644, 649}, {65, 65}}, lengths: []int{16, 17, 18, 19}},
+	"jcb":        {prefixes: [][2]int{{3528, 3589}}, lengths: []int{16, 17, 18, 19}},
+	"unionpay":   {prefixes: [][2]int{{62, 62}}, lengths: []int{16, 17, 18, 19}},
+}
+
+// ibanLengths is the length of the IBAN per country. Ref: https://www.swift.com/standards/data-standards/iban
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BR": 29,
+	"BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DK": 18, "DO": 28, "EE": 20, "EG": 29,
+	"ES": 24, "FI": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28,
+	"HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20,
+	"LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24, "ME": 22, "MK": 19,
+	"MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29,
+	"RO": 24, "RS": 22, "SA": 24, "SC": 31, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
+
+// stripSeparators remove the spaces and hyphens used to group digits
+func stripSeparators(str string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(str)
+}
+
+// isLuhn check the digits pass the Luhn (mod 10) checksum
+func isLuhn(digits string) bool {
+	if digits == "" || !isDigits(digits) {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+// cardBrandOf return the brand matching the card number prefix and length
+func cardBrandOf(number string) (string, bool) {
+	for name, brand := range cardBrands {
+		if brand.match(number) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+func (b cardBrand) match(number string) bool {
+	lengthOk := false
+	for _, l := range b.lengths {
+		if len(number) == l {
+			lengthOk = true
+			break
+		}
+	}
+
+	if !lengthOk {
+		return false
+	}
+
+	for _, p := range b.prefixes {
+		size := len(strconv.Itoa(p[0]))
+		if len(number) < size {
+			continue
+		}
+
+		prefix, err := strconv.Atoi(number[:size])
+		if err == nil && prefix >= p[0] && prefix <= p[1] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isISBN10Checksum check the ISBN-10 weighted sum is a multiple of 11,
+// the last character can be X for 10
+func isISBN10Checksum(isbn string) bool {
+	if len(isbn) != 10 {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 10; i++ {
+		c := isbn[i]
+		var d int
+		switch {
+		case c >= '0' && c <= '9':
+			d = int(c - '0')
+		case i == 9 && (c == 'X' || c == 'x'):
+			d = 10
+		default:
+			return false
+		}
+		sum += (10 - i) * d
+	}
+
+	return sum%11 == 0
+}
+
+// isISBN13Checksum check the ISBN-13 digits weighted 1 and 3 sum to a multiple of 10
+func isISBN13Checksum(isbn string) bool {
+	if len(isbn) != 13 || !isDigits(isbn) {
+		return false
+	}
+
+	if !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 13; i++ {
+		d := int(isbn[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+
+	return sum%10 == 0
+}
+
+// isIBANChecksum check the country length and the ISO 7064 mod 97-10 checksum
+func isIBANChecksum(iban string) bool {
+	if len(iban) < 5 {
+		return false
+	}
+
+	length, ok := ibanLengths[iban[:2]]
+	if !ok || len(iban) != length {
+		return false
+	}
+
+	// move the country code and check digits to the end, letters become 10..35
+	rearranged := iban[4:] + iban[:4]
+	rem := 0
+	for i := 0; i < len(rearranged); i++ {
+		c := rearranged[i]
+		switch {
+		case c >= '0' && c <= '9':
+			rem = (rem*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			rem = (rem*100 + int(c-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+
+	return rem == 1
+}
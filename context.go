@@ -0,0 +1,51 @@
+// Package validator
+package validator
+
+import (
+	"reflect"
+)
+
+// ruleContext carries what a rule needs beyond its own value
+type ruleContext struct {
+	validator *Validator
+	// walker tracks the nesting of the struct being validated, nil for a single value
+	walker *walker
+	// lookup resolve the value of a sibling field
+	lookup func(name string) (interface{}, bool)
+}
+
+type contextRuleFunc func(ctx *ruleContext, value interface{}, fieldName, tagRule string, isRequired bool) error
+
+// contextRules are the rules depending on the validator options or the sibling fields
+var contextRules = map[string]contextRuleFunc{
+	"before":          ValidBefore,
+	"before_or_equal": ValidBeforeOrEqual,
+	"after":           ValidAfter,
+	"after_or_equal":  ValidAfterOrEqual,
+	"date_between":    ValidDateBetween,
+	"email_mx":        ValidEmailMX,
+	"password":        ValidPassword,
+	"unique":          ValidUnique,
+	"id_nik":          ValidNIK,
+	"id_npwp":         ValidNPWP,
+	"id_kk":           ValidKK,
+}
+
+// structLookup resolve a sibling field by its Go name, its tag field name without
+// the tag options, or the name it is given in the error bag by the validator
+func structLookup(vl *Validator, v reflect.Value) func(name string) (interface{}, bool) {
+	return func(name string) (interface{}, bool) {
+		for _, f := range structFields(v.Type(), vl.TagField) {
+			if f.PkgPath != "" || f.promoted {
+				continue
+			}
+
+			if f.Name == name || f.name == name || vl.fieldName(f.StructField) == name {
+				fv, _ := fieldByIndex(v, f.Index)
+				return fv.Interface(), true
+			}
+		}
+
+		return nil, false
+	}
+}
@@ -0,0 +1,47 @@
+// Package validator
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const defaultTagDefault = `default`
+
+// applyDefault set the default value of a field when it is empty, slices take
+// a comma separated list, e.g. `default:"a,b,c"`, and a nil pointer to struct
+// is allocated so the defaults of its own fields can be applied
+func applyDefault(v reflect.Value, value string) error {
+	if !isEmpty(v.Interface()) {
+		return nil
+	}
+
+	switch {
+	case v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct && v.Type().Elem() != timeType:
+		v.Set(reflect.New(v.Type().Elem()))
+		return nil
+	case v.Kind() == reflect.Struct && v.Type() != timeType:
+		return nil
+	}
+
+	vals := []string{value}
+	if isSlice(v.Type()) {
+		vals = strings.Split(value, ",")
+	}
+
+	if err := setValue(v, vals, ""); err != nil {
+		return fmt.Errorf("%s", v.Type().String())
+	}
+
+	return nil
+}
+
+// isSlice check the type, or the type it points to, is a slice other than []byte
+func isSlice(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
@@ -0,0 +1,113 @@
+// Package validator
+package validator
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+)
+
+// KeyStyle is the way the error bag keys of the collection elements are written
+type KeyStyle int
+
+const (
+	// KeyStyleBracket writes the element keys as labels[env] and items[0].sku
+	KeyStyleBracket KeyStyle = iota
+	// KeyStyleDot writes the element keys as labels.env and items.0.sku
+	KeyStyleDot
+)
+
+const (
+	ruleDive    = `dive`
+	ruleKeys    = `keys`
+	ruleEndKeys = `endkeys`
+)
+
+// splitDive split the rules of a field from the rules of its elements which follow dive
+func splitDive(tags []string) ([]string, []string, bool) {
+	for i, tag := range tags {
+		if tag == ruleDive {
+			return tags[:i], tags[i+1:], true
+		}
+	}
+
+	return tags, nil, false
+}
+
+// splitKeys split the rules of the map keys, enclosed in keys and endkeys right
+// after dive, from the rules of the map values
+func splitKeys(tags []string) ([]string, []string, bool) {
+	if len(tags) == 0 || tags[0] != ruleKeys {
+		return nil, tags, true
+	}
+
+	for i, tag := range tags {
+		if tag == ruleEndKeys {
+			return tags[1:i], tags[i+1:], true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// elementKey return the error bag key of a collection element
+func (vl *Validator) elementKey(parentField string, key interface{}) string {
+	if vl.KeyStyle == KeyStyleDot {
+		return fmt.Sprintf("%s.%v", parentField, key)
+	}
+
+	return fmt.Sprintf("%s[%v]", parentField, key)
+}
+
+// hasNestedStruct check the elements of the collection type are structs to descend into
+func hasNestedStruct(t reflect.Type) bool {
+	e := t.Elem()
+	if e.Kind() == reflect.Ptr {
+		e = e.Elem()
+	}
+
+	return e.Kind() == reflect.Struct && e != timeType
+}
+
+// validateElements run the rules following dive on the elements of a slice, an array
+// or a map, and the rules between keys and endkeys on the map keys, then descend
+// into the elements being structs. The map keys are walked in sorted order
+func validateElements(vl *Validator, ctx *ruleContext, v reflect.Value, key string, tags []string, dive bool, errBag url.Values) {
+	if !dive && !hasNestedStruct(v.Type()) {
+		return
+	}
+
+	if ctx.walker != nil {
+		if !ctx.walker.enter(v, key, errBag) {
+			return
+		}
+		defer ctx.walker.leave(v)
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			validateValue(vl, ctx, v.Index(i), vl.elementKey(key, i), tags, errBag)
+		}
+	case reflect.Map:
+		keyTags, valueTags, ok := splitKeys(tags)
+		if !ok {
+			errBag.Add(key, fmt.Sprintf(`The %s field invalid rule format %s without %s`, key, ruleKeys, ruleEndKeys))
+			return
+		}
+
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return ToString(keys[i].Interface()) < ToString(keys[j].Interface())
+		})
+
+		for _, k := range keys {
+			ek := vl.elementKey(key, ToString(k.Interface()))
+			if len(keyTags) > 0 {
+				validate(ctx, k.Interface(), ek, keyTags, errBag)
+			}
+			validateValue(vl, ctx, v.MapIndex(k), ek, valueTags, errBag)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+// Package validator
+package validator
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unsafe"
+)
+
+// NamingStrategy is the way the Go name of a field without tag field name is
+// written in the error bag
+type NamingStrategy int
+
+const (
+	// NamingGo keeps the Go name, e.g. UserID
+	NamingGo NamingStrategy = iota
+	// NamingSnakeCase writes the Go name in snake case, e.g. user_id
+	NamingSnakeCase
+	// NamingCamelCase writes the Go name in camel case, e.g. userID
+	NamingCamelCase
+)
+
+// FieldNameFunc name a struct field in the error bag, an empty name falls back
+// to the tag field name and the naming strategy
+type FieldNameFunc func(field reflect.StructField) string
+
+// rename apply the naming strategy on a Go name
+func (n NamingStrategy) rename(name string) string {
+	switch n {
+	case NamingSnakeCase:
+		return snakeCase(name)
+	case NamingCamelCase:
+		return camelCase(name)
+	}
+	return name
+}
+
+// snakeCase write the Go name in snake case, the acronyms are kept as one
+// word, e.g. HTTPServerID becomes http_server_id
+func snakeCase(name string) string {
+	rs := []rune(name)
+
+	var b strings.Builder
+	for i, r := range rs {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := rs[i-1]
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
+
+// camelCase write the Go name in camel case, a leading acronym is lowered
+// as a whole, e.g. HTTPServer becomes httpServer and ID becomes id
+func camelCase(name string) string {
+	rs := []rune(name)
+
+	n := 0
+	for n < len(rs) && unicode.IsUpper(rs[n]) {
+		n++
+	}
+
+	// the last upper letter of an acronym opens the next word
+	if n > 1 && n < len(rs) && unicode.IsLower(rs[n]) {
+		n--
+	}
+
+	for i := 0; i < n; i++ {
+		rs[i] = unicode.ToLower(rs[i])
+	}
+
+	return string(rs)
+}
+
+// structField is a field of a struct, Index is the index sequence from the
+// walked struct as the fields of the embedded structs are promoted
+type structField struct {
+	reflect.StructField
+	name     string // tag name, or the Go name when untagged
+	tagged   bool
+	depth    int
+	promoted bool // embedded or inline struct whose fields are promoted
+}
+
+// structFieldsKey is the key of the fields cache
+type structFieldsKey struct {
+	t        reflect.Type
+	tagField string
+}
+
+var structFieldsCache sync.Map
+
+// tagOptions are the comma separated options following the name in a tag
+type tagOptions string
+
+// parseTag split a tag like `json:"name,omitempty"` into its name and options
+func parseTag(tag string) (string, tagOptions) {
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, tagOptions(opts)
+}
+
+// Contains check the option is present
+func (o tagOptions) Contains(option string) bool {
+	for o != "" {
+		opt, rest, _ := strings.Cut(string(o), ",")
+		if opt == option {
+			return true
+		}
+		o = tagOptions(rest)
+	}
+	return false
+}
+
+// tagFieldName return the name of the field tag, empty when the field has no tag
+// name or is tagged "-" which only opts out of encoding/json
+func tagFieldName(fi reflect.StructField, tagField string) string {
+	tag := fi.Tag.Get(tagField)
+	if tag == "-" {
+		return ""
+	}
+
+	name, _ := parseTag(tag)
+	return name
+}
+
+// isPromoted check the fields of the struct field are promoted to its parent:
+// an embedded struct without tag name, as encoding/json does, or a struct
+// field with the inline option like `json:",inline"`
+func isPromoted(fi reflect.StructField, tagField string) bool {
+	t := fi.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || t == timeType {
+		return false
+	}
+
+	name, opts := parseTag(fi.Tag.Get(tagField))
+	if opts.Contains("inline") {
+		return true
+	}
+
+	return fi.Anonymous && name == ""
+}
+
+// structFields list the fields of the struct type with the fields of the embedded
+// structs promoted, in declaration order. As in encoding/json a promoted field
+// is shadowed by a field of the same name at a shallower depth, at the same
+// depth the tagged field wins and the fields left are dropped as ambiguous,
+// like the fields of a struct type embedded twice. The embedded structs are
+// listed as well, flagged promoted, so the rules on them can run
+func structFields(t reflect.Type, tagField string) []structField {
+	key := structFieldsKey{t, tagField}
+	if f, ok := structFieldsCache.Load(key); ok {
+		return f.([]structField)
+	}
+
+	// level is a struct to walk, chain holds the types embedding it so a type
+	// embedding itself is walked once while the same type embedded twice is not
+	type level struct {
+		t     reflect.Type
+		index []int
+		chain map[reflect.Type]bool
+	}
+
+	var fields []structField
+	current := []level{{t: t, chain: map[reflect.Type]bool{t: true}}}
+
+	for depth := 0; len(current) > 0; depth++ {
+		var next []level
+		for _, l := range current {
+			for i := 0; i < l.t.NumField(); i++ {
+				fi := l.t.Field(i)
+				fi.Index = append(append([]int{}, l.index...), i)
+
+				name := tagFieldName(fi, tagField)
+				f := structField{StructField: fi, name: name, tagged: name != "", depth: depth}
+				if !f.tagged {
+					f.name = fi.Name
+				}
+
+				if isPromoted(fi, tagField) {
+					f.promoted = true
+					fields = append(fields, f)
+
+					ft := fi.Type
+					if ft.Kind() == reflect.Ptr {
+						// the pointer to an unexported struct can not be followed
+						if fi.PkgPath != "" {
+							continue
+						}
+						ft = ft.Elem()
+					}
+
+					if l.chain[ft] {
+						continue
+					}
+
+					chain := make(map[reflect.Type]bool, len(l.chain)+1)
+					for ct := range l.chain {
+						chain[ct] = true
+					}
+					chain[ft] = true
+
+					next = append(next, level{t: ft, index: fi.Index, chain: chain})
+					continue
+				}
+
+				fields = append(fields, f)
+			}
+		}
+		current = next
+	}
+
+	fields = dominantFields(fields)
+
+	f, _ := structFieldsCache.LoadOrStore(key, fields)
+	return f.([]structField)
+}
+
+// dominantFields keep the field winning each name, ordered by index sequence,
+// the promoted structs are all kept as they do not compete with the fields
+// promoted from them
+func dominantFields(fields []structField) []structField {
+	var promoted []structField
+	named := fields[:0:0]
+	for _, f := range fields {
+		if f.promoted {
+			promoted = append(promoted, f)
+		} else {
+			named = append(named, f)
+		}
+	}
+	fields = named
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].name != fields[j].name {
+			return fields[i].name < fields[j].name
+		}
+		if fields[i].depth != fields[j].depth {
+			return fields[i].depth < fields[j].depth
+		}
+		return fields[i].tagged && !fields[j].tagged
+	})
+
+	out := fields[:0:0]
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+
+		group := fields[i:j]
+		if len(group) == 1 || group[0].depth < group[1].depth ||
+			group[0].tagged && !group[1].tagged {
+			out = append(out, group[0])
+		}
+		i = j
+	}
+	out = append(out, promoted...)
+
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i].Index, out[j].Index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+
+	return out
+}
+
+// fieldByIndex return the field at the index sequence, or its zero value and false
+// when it belongs to an embedded struct reached through a nil pointer
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Zero(v.Type().Elem().FieldByIndex(index[i:]).Type), false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+// readable return a value which can be read through Interface, an unexported
+// field is read through its address, it can not be read when not addressable
+func readable(v reflect.Value) (reflect.Value, bool) {
+	if v.CanInterface() {
+		return v, true
+	}
+
+	if !v.CanAddr() {
+		return v, false
+	}
+
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem(), true
+}
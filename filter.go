@@ -0,0 +1,156 @@
+// Package validator
+package validator
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const defaultTagFilter = `filter`
+
+type filterFunc func(value, param string) string
+
+var (
+	filters = map[string]filterFunc{
+		"trim":        filterTrim,
+		"ltrim":       filterLTrim,
+		"rtrim":       filterRTrim,
+		"lower":       filterLower,
+		"upper":       filterUpper,
+		"squish":      filterSquish,
+		"strip_tags":  filterStripTags,
+		"digits_only": filterDigitsOnly,
+		"e164":        filterE164,
+		"nfc":         filterNFC,
+	}
+
+	regexHTMLTag = regexp.MustCompile(`<[^>]*>`)
+)
+
+// AddFilter register a custom filter, the param receives the text after
+// the colon of the filter tag, e.g. `filter:"truncate:10"`
+func AddFilter(name string, fn filterFunc) error {
+	if _, ok := filters[name]; ok {
+		return fmt.Errorf("validator: %s is already defined in filters", name)
+	}
+
+	filters[name] = fn
+	return nil
+}
+
+// Filter apply the filter tags on the struct pointed by input
+func (vl *Validator) Filter(input interface{}) error {
+	val := reflect.ValueOf(input)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("validator: filter requires a non-nil pointer to struct, got %T", input)
+	}
+
+	prepareStruct(vl, nil, val.Elem(), "", "", vl.TagFilter, url.Values{})
+
+	return nil
+}
+
+// applyFilters run the filters on string, *string and []string values,
+// unknown filters are ignored the same way unknown rules are
+func applyFilters(v reflect.Value, names []string) {
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		for _, name := range names {
+			fn, param := name, ""
+			if i := strings.Index(name, ":"); i >= 0 {
+				fn, param = name[:i], name[i+1:]
+			}
+
+			f, ok := filters[fn]
+			if !ok {
+				continue
+			}
+
+			s = f(s, param)
+		}
+		v.SetString(s)
+	case reflect.Ptr:
+		if !v.IsNil() {
+			applyFilters(v.Elem(), names)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			applyFilters(v.Index(i), names)
+		}
+	}
+}
+
+// needsPrepare check the struct type, or one of its nested structs, has a default
+// or a filter tag, the structs without any are not walked by prepareStruct
+func needsPrepare(t reflect.Type, tagDefault, tagFilter string) bool {
+	return tagDefault != "" && hasTag(t, tagDefault, map[reflect.Type]bool{}) ||
+		tagFilter != "" && hasTag(t, tagFilter, map[reflect.Type]bool{})
+}
+
+// hasTag check the struct type or its nested structs declare the tag
+func hasTag(t reflect.Type, tag string, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || t == timeType || seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		fi := t.Field(i)
+		if _, ok := fi.Tag.Lookup(tag); ok {
+			return true
+		}
+
+		if hasTag(fi.Type, tag, seen) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func filterTrim(value, param string) string {
+	return strings.TrimSpace(value)
+}
+
+func filterLTrim(value, param string) string {
+	return strings.TrimLeftFunc(value, unicode.IsSpace)
+}
+
+func filterRTrim(value, param string) string {
+	return strings.TrimRightFunc(value, unicode.IsSpace)
+}
+
+func filterLower(value, param string) string {
+	return strings.ToLower(value)
+}
+
+func filterUpper(value, param string) string {
+	return strings.ToUpper(value)
+}
+
+// filterSquish trim the value and collapse the inner whitespaces into a single space
+func filterSquish(value, param string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func filterStripTags(value, param string) string {
+	return regexHTMLTag.ReplaceAllString(value, "")
+}
+
+func filterDigitsOnly(value, param string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
@@ -20,7 +20,7 @@ func isRuleExist(rule string) bool {
 	if strings.Contains(rule, ":") {
 		rule = strings.Split(rule, ":")[0]
 	}
-	extendedRules := []string{"size", "mime", "ext"}
+	extendedRules := []string{"mime", "ext"}
 	for _, r := range extendedRules {
 		if r == rule {
 			return true
@@ -29,6 +29,48 @@ func isRuleExist(rule string) bool {
 	if _, ok := rules[rule]; ok {
 		return true
 	}
+	if _, ok := contextRules[rule]; ok {
+		return true
+	}
 
 	return false
-}
\ No newline at end of file
+}
+
+// ruleParam return the parameter of a rule, the text after the first colon
+func ruleParam(rule string) string {
+	if i := strings.Index(rule, ":"); i >= 0 {
+		return rule[i+1:]
+	}
+	return ""
+}
+
+// ruleParams return the comma separated parameters of a rule
+func ruleParams(rule string) []string {
+	param := ruleParam(rule)
+	if param == "" {
+		return nil
+	}
+	return strings.Split(param, ",")
+}
+
+// splitRules split a rule tag on the pipes, an escaped pipe \| is kept
+// in the rule so it can be used by the regex rule
+func splitRules(tag string) []string {
+	var rules []string
+	var rule strings.Builder
+
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == '|':
+			rule.WriteByte('|')
+			i++
+		case tag[i] == '|':
+			rules = append(rules, rule.String())
+			rule.Reset()
+		default:
+			rule.WriteByte(tag[i])
+		}
+	}
+
+	return append(rules, rule.String())
+}
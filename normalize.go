@@ -0,0 +1,26 @@
+// Package validator
+package validator
+
+import (
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxMarks is the number of combining marks accepted after a letter once normalized
+// to NFC, enough for the syllables of the scripts like Javanese while rejecting the
+// text stacking marks on a letter
+const maxMarks = 4
+
+// normalizeNFC normalize the text to the Unicode canonical composition, so a name typed
+// on a keyboard producing decomposed letters compares equal to the precomposed one
+func normalizeNFC(str string) string {
+	if isASCII(str) {
+		return str
+	}
+
+	return norm.NFC.String(str)
+}
+
+// filterNFC normalize the text to the composed form
+func filterNFC(value, param string) string {
+	return normalizeNFC(value)
+}
@@ -1,7 +1,10 @@
 // Package validator
 package validator
 
-import "regexp"
+import (
+	"regexp"
+	"sync"
+)
 
 const (
 	// Alpha represents regular expression for alpha characters
@@ -17,7 +20,7 @@ const (
 	// Coordinate represents latitude and longitude regular expression
 	Coordinate string = "^[-+]?([1-8]?\\d(\\.\\d+)?|90(\\.0+)?),\\s*[-+]?(180(\\.0+)?|((1[0-7]\\d)|([1-9]?\\d))(\\.\\d+)?)$" // Ref: https://stackoverflow.com/questions/3518504/regular-expression-for-matching-latitude-longitude-coordinates
 	// CSSColor represents css valid color code with hex, rgb, rgba, hsl, hsla etc. Ref: http://www.regexpal.com/97509
-	CSSColor string = "^(#([\\da-f]{3}){1,2}|(rgb|hsl)a\\((\\d{1,3}%?,\\s?){3}(1|0?\\.\\d+)\\)|(rgb|hsl)\\(\\d{1,3}%?(,\\s?\\d{1,3}%?){2}\\))$"
+	CSSColor string = "^(#([\\da-fA-F]{3}){1,2}|(rgb|hsl)a\\((\\d{1,3}%?,\\s?){3}(1|0?\\.\\d+)\\)|(rgb|hsl)\\(\\d{1,3}%?(,\\s?\\d{1,3}%?){2}\\))$"
 	// Date represents regular expression for valid date like: yyyy-mm-dd
 	Date string = "^(((19|20)([2468][048]|[13579][26]|0[48])|2000)[/-]02[/-]29|((19|20)[0-9]{2}[/-](0[469]|11)[/-](0[1-9]|[12][0-9]|30)|(19|20)[0-9]{2}[/-](0[13578]|1[02])[/-](0[1-9]|[12][0-9]|3[01])|(19|20)[0-9]{2}[/-]02[/-](0[1-9]|1[0-9]|2[0-8])))$"
 	// DateDDMMYY represents regular expression for valid date of format dd/mm/yyyy , dd-mm-yyyy etc.Ref: http://regexr.com/346hf
@@ -31,23 +34,25 @@ const (
 	// IP represents regular expression for ip address
 	IP string = "^(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])$"
 	// IPV4 represents regular expression for ip address version 4
-	IPV4 string = "^([0-9]{1,3}\\.){3}[0-9]{1,3}(\\/([0-9]|[1-2][0-9]|3[0-2]))?$"
+	IPV4 string = "^((25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\\.){3}(25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])(\\/([0-9]|[1-2][0-9]|3[0-2]))?$"
 	// IPV6 represents regular expression for ip address version 6
-	IPV6 string = `^s*((([0-9A-Fa-f]{1,4}:){7}([0-9A-Fa-f]{1,4}|:))|(([0-9A-Fa-f]{1,4}:){6}(:[0-9A-Fa-f]{1,4}|((25[0-5]|2[0-4]d|1dd|[1-9]?d)(.(25[0-5]|2[0-4]d|1dd|[1-9]?d)){3})|:))|(([0-9A-Fa-f]{1,4}:){5}(((:[0-9A-Fa-f]{1,4}){1,2})|:((25[0-5]|2[0-4]d|1dd|[1-9]?d)(.(25[0-5]|2[0-4]d|1dd|[1-9]?d)){3})|:))|(([0-9A-Fa-f]{1,4}:){4}(((:[0-9A-Fa-f]{1,4}){1,3})|((:[0-9A-Fa-f]{1,4})?:((25[0-5]|2[0-4]d|1dd|[1-9]?d)(.(25[0-5]|2[0-4]d|1dd|[1-9]?d)){3}))|:))|(([0-9A-Fa-f]{1,4}:){3}(((:[0-9A-Fa-f]{1,4}){1,4})|((:[0-9A-Fa-f]{1,4}){0,2}:((25[0-5]|2[0-4]d|1dd|[1-9]?d)(.(25[0-5]|2[0-4]d|1dd|[1-9]?d)){3}))|:))|(([0-9A-Fa-f]{1,4}:){2}(((:[0-9A-Fa-f]{1,4}){1,5})|((:[0-9A-Fa-f]{1,4}){0,3}:((25[0-5]|2[0-4]d|1dd|[1-9]?d)(.(25[0-5]|2[0-4]d|1dd|[1-9]?d)){3}))|:))|(([0-9A-Fa-f]{1,4}:){1}(((:[0-9A-Fa-f]{1,4}){1,6})|((:[0-9A-Fa-f]{1,4}){0,4}:((25[0-5]|2[0-4]d|1dd|[1-9]?d)(.(25[0-5]|2[0-4]d|1dd|[1-9]?d)){3}))|:))|(:(((:[0-9A-Fa-f]{1,4}){1,7})|((:[0-9A-Fa-f]{1,4}){0,5}:((25[0-5]|2[0-4]d|1dd|[1-9]?d)(.(25[0-5]|2[0-4]d|1dd|[1-9]?d)){3}))|:)))(%.+)?s*(\/([0-9]|[1-9][0-9]|1[0-1][0-9]|12[0-8]))?$`
+	IPV6 string = `^\s*((([0-9A-Fa-f]{1,4}:){7}([0-9A-Fa-f]{1,4}|:))|(([0-9A-Fa-f]{1,4}:){6}(:[0-9A-Fa-f]{1,4}|((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3})|:))|(([0-9A-Fa-f]{1,4}:){5}(((:[0-9A-Fa-f]{1,4}){1,2})|:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3})|:))|(([0-9A-Fa-f]{1,4}:){4}(((:[0-9A-Fa-f]{1,4}){1,3})|((:[0-9A-Fa-f]{1,4})?:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(([0-9A-Fa-f]{1,4}:){3}(((:[0-9A-Fa-f]{1,4}){1,4})|((:[0-9A-Fa-f]{1,4}){0,2}:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(([0-9A-Fa-f]{1,4}:){2}(((:[0-9A-Fa-f]{1,4}){1,5})|((:[0-9A-Fa-f]{1,4}){0,3}:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(([0-9A-Fa-f]{1,4}:){1}(((:[0-9A-Fa-f]{1,4}){1,6})|((:[0-9A-Fa-f]{1,4}){0,4}:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(:(((:[0-9A-Fa-f]{1,4}){1,7})|((:[0-9A-Fa-f]{1,4}){0,5}:((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(\.(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:)))(%.+)?\s*(\/([0-9]|[1-9][0-9]|1[0-1][0-9]|12[0-8]))?$`
 	// Latitude represents latitude regular expression
-	Latitude     string = "^[-+]?([1-8]?\\d(\\.\\d+)?|90(\\.0+)?)$"
+	Latitude string = "^[-+]?([1-8]?\\d(\\.\\d+)?|90(\\.0+)?)$"
 	// Longitude represents longitude regular expression
-	Longitude    string = "^[-+]?(180(\\.0+)?|((1[0-7]\\d)|([1-9]?\\d))(\\.\\d+)?)$"
+	Longitude string = "^[-+]?(180(\\.0+)?|((1[0-7]\\d)|([1-9]?\\d))(\\.\\d+)?)$"
 	// MacAddress represents regular expression for mac address
 	MacAddress string = "^([0-9A-Fa-f]{2}[:-]){5}([0-9A-Fa-f]{2})$"
-	// Numeric represents regular expression for numeric
-	Numeric string = "^[0-9]+"
+	// Numeric represents regular expression for numeric, signed integer or decimal
+	Numeric string = "^[-+]?[0-9]+(\\.[0-9]+)?$"
 	// HexColor represents regular expression for hexa color
 	HexColor string = "^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$"
 	// Semver represents regular expression for semantic version
 	Semver string = "^v?(?:0|[1-9]\\d*)\\.(?:0|[1-9]\\d*)\\.(?:0|[1-9]\\d*)(-(0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(\\.(0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*)?(\\+[0-9a-zA-Z-]+(\\.[0-9a-zA-Z-]+)*)?$"
-	// PhoneFormatIndonesia represents regular expression for indonesian phone number format
-	PhoneFormatIndonesia = `^([62]|[0])[0-9]+$`
+	// PhoneFormatIndonesia represents regular expression for indonesian mobile phone number format with +62, 62 or 0 prefix
+	PhoneFormatIndonesia = `^(\+62|62|0)8[1-9][0-9]{7,10}$`
+	// E164 represents regular expression for international phone number in E.164 format
+	E164 string = `^\+[1-9][0-9]{1,14}$`
 	// URL represents regular expression for url
 	//URL string = "^(?:http(s)?:\\/\\/)?[\\w.-]+(?:\\.[\\w\\.-]+)+[\\w\\-\\._~:/?#[\\]@!\\$&'\\(\\)\\*\\+,;=.]+$" // Ref: https://stackoverflow.com/questions/136505/searching-for-uuids-in-text-with-regex
 	URLSchema    string = `((ftp|tcp|udp|wss?|https?):\/\/)`
@@ -58,34 +63,29 @@ const (
 	URLSubdomain string = `((www\.)|([a-zA-Z0-9]+([-_\.]?[a-zA-Z0-9])*[a-zA-Z0-9]\.[a-zA-Z0-9]+))`
 	// URL represents regular expression for url
 	URL = `^` + URLSchema + `?` + URLUsername + `?` + `((` + URLIP + `|(\[` + IP + `\])|(([a-zA-Z0-9]([a-zA-Z0-9-_]+)?[a-zA-Z0-9]([-\.][a-zA-Z0-9]+)*)|(` + URLSubdomain + `?))?(([a-zA-Z\x{00a1}-\x{ffff}0-9]+-?-?)*[a-zA-Z\x{00a1}-\x{ffff}0-9]+)(?:\.([a-zA-Z\x{00a1}-\x{ffff}]{1,}))?))\.?` + URLPort + `?` + URLPath + `?$`
-	// UUID represents regular expression for UUID
-	UUID string = "^[a-f0-9]{8}-[a-f0-9]{4}-4[a-f0-9]{3}-[89aAbB][a-f0-9]{3}-[a-f0-9]{12}$"
+	// UUID represents regular expression for UUID of any version
+	UUID string = "^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$"
 	// UUID3 represents regular expression for UUID version 3
-	UUID3 string = "^[0-9a-f]{8}-[0-9a-f]{4}-3[0-9a-f]{3}-[0-9a-f]{4}-[0-9a-f]{12}$"
+	UUID3 string = "^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-3[0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$"
 	// UUID4 represents regular expression for UUID version 4
-	UUID4 string = "^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$"
+	UUID4 string = "^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-4[0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$"
 	// UUID5 represents regular expression for UUID version 5
-	UUID5 string = "^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$"
+	UUID5 string = "^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-5[0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$"
 	// UUID5 represents regular expression for IMEI
 	IMEI string = "^[0-9a-f]{14}$|^\\d{15}$|^\\d{18}$"
 	// ISBN10 represents regular expression for ISBN10
 	ISBN10 string = "^(?:[0-9]{9}X|[0-9]{10})$"
 	// ISBN13 represents regular expression for ISBN13
 	ISBN13 string = "^(?:[0-9]{13})$"
+
+	// digitsOnly represents regular expression for the digits rule, unsigned digits only
+	digitsOnly string = "^[0-9]+$"
 )
 
 var (
-	regexAlpha         = regexp.MustCompile(Alpha)
-	regexAlphaDash     = regexp.MustCompile(AlphaDash)
-	regexAlphaSpace    = regexp.MustCompile(AlphaSpace)
-	regexAlphaNumeric  = regexp.MustCompile(AlphaNumeric)
-	regexCreditCard    = regexp.MustCompile(CreditCard)
 	regexCoordinate    = regexp.MustCompile(Coordinate)
 	regexCSSColor      = regexp.MustCompile(CSSColor)
-	regexDate          = regexp.MustCompile(Date)
-	regexDateDDMMYY    = regexp.MustCompile(DateDDMMYY)
-	regexDigits        = regexp.MustCompile(Digits)
-	regexEmail         = regexp.MustCompile(Email)
+	regexDigitsOnly    = regexp.MustCompile(digitsOnly)
 	regexFloat         = regexp.MustCompile(Float)
 	regexMacAddress    = regexp.MustCompile(MacAddress)
 	regexNumeric       = regexp.MustCompile(Numeric)
@@ -93,16 +93,24 @@ var (
 	regexLongitude     = regexp.MustCompile(Longitude)
 	regexHexColor      = regexp.MustCompile(HexColor)
 	regexSemver        = regexp.MustCompile(Semver)
-	regexIP            = regexp.MustCompile(IP)
-	regexIPV4          = regexp.MustCompile(IPV4)
-	regexIPV6          = regexp.MustCompile(IPV6)
-	regexURL           = regexp.MustCompile(URL)
-	regexUUID          = regexp.MustCompile(UUID)
-	regexUUID3         = regexp.MustCompile(UUID3)
-	regexUUID4         = regexp.MustCompile(UUID4)
-	regexUUID5         = regexp.MustCompile(UUID5)
-	regexIMEI          = regexp.MustCompile(IMEI)
-	regexISBN10        = regexp.MustCompile(ISBN10)
-	regexISBN13        = regexp.MustCompile(ISBN13)
 	regexPhoneNumberID = regexp.MustCompile(PhoneFormatIndonesia)
+	regexE164          = regexp.MustCompile(E164)
 )
+
+// regexCache holds the patterns of the regex rule, compiled once
+var regexCache sync.Map
+
+// compileRegex compile the pattern or return the cached regular expression
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if rx, ok := regexCache.Load(pattern); ok {
+		return rx.(*regexp.Regexp), nil
+	}
+
+	rx, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	regexCache.Store(pattern, rx)
+	return rx, nil
+}
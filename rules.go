@@ -4,6 +4,7 @@ package validator
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -11,36 +12,104 @@ type ruleFunc func(value interface{}, fieldName, tagRule string, isRequired bool
 
 var (
 	rules = map[string]ruleFunc{
-		"required":    Required,
-		"numeric":     ValidNumeric,
-		"float":       ValidFloat,
-		"max":         ValidMax,
-		"min":         ValidMin,
-		"alpha_num":   ValidAlphaNum,
-		"alpha_space": ValidAlphaSpace,
-		"alpha_dash":  ValidAlphaDash,
-		"email":       ValidEmail,
-		"uuid":        ValidUUID,
-		"uuid3":       ValidUUID3,
-		"uuid4":       ValidUUID4,
-		"uuid5":       ValidUUID5,
-		"url":         ValidURL,
-		"credit_card": ValidCreditCard,
-		"latitude":    ValidLatitude,
-		"longitude":   ValidLongitude,
-		"mac_address": ValidMacAddress,
-		"coordinate":  ValidCoordinate,
-		"ip":          ValidIP,
-		"ipv4":        ValidIPV4,
-		"ipv6":        ValidIPV6,
-		"imei":        ValidIMEI,
-		"hex_color":   ValidHexColor,
-		"isbn10":      ValidISBN10,
-		"isbn13":      ValidISBN13,
-		"json":        ValidJSON,
-		"bool":        ValidBoolean,
-		"in":          ValidIn,
-		"id_phone":    ValidIndonesianPhoneNumber,
+		"required":            Required,
+		"numeric":             ValidNumeric,
+		"float":               ValidFloat,
+		"max":                 ValidMax,
+		"min":                 ValidMin,
+		"between":             ValidBetween,
+		"len":                 ValidLen,
+		"min_len":             ValidMinLen,
+		"max_len":             ValidMaxLen,
+		"size":                ValidSize,
+		"gt":                  ValidGt,
+		"gte":                 ValidGte,
+		"lt":                  ValidLt,
+		"lte":                 ValidLte,
+		"alpha":               ValidAlpha,
+		"alpha_num":           ValidAlphaNum,
+		"alpha_space":         ValidAlphaSpace,
+		"alpha_dash":          ValidAlphaDash,
+		"person_name":         ValidPersonName,
+		"email":               ValidEmail,
+		"email_domain_in":     ValidEmailDomainIn,
+		"email_domain_not_in": ValidEmailDomainNotIn,
+		"uuid":                ValidUUID,
+		"uuid3":               ValidUUID3,
+		"uuid4":               ValidUUID4,
+		"uuid5":               ValidUUID5,
+		"ulid":                ValidULID,
+		"ksuid":               ValidKSUID,
+		"base64":              ValidBase64,
+		"base64url":           ValidBase64URL,
+		"base64_raw":          ValidBase64Raw,
+		"hex":                 ValidHex,
+		"hexadecimal":         ValidHexadecimal,
+		"jwt":                 ValidJWT,
+		"data_uri":            ValidDataURI,
+		"url":                 ValidURL,
+		"url_host_in":         ValidURLHostIn,
+		"url_no_credentials":  ValidURLNoCredentials,
+		"url_no_private_ip":   ValidURLNoPrivateIP,
+		"uri":                 ValidURI,
+		"credit_card":         ValidCreditCard,
+		"iban":                ValidIBAN,
+		"latitude":            ValidLatitude,
+		"longitude":           ValidLongitude,
+		"mac_address":         ValidMacAddress,
+		"coordinate":          ValidCoordinate,
+		"ip":                  ValidIP,
+		"ipv4":                ValidIPV4,
+		"ipv6":                ValidIPV6,
+		"cidr":                ValidCIDR,
+		"cidrv4":              ValidCIDRV4,
+		"cidrv6":              ValidCIDRV6,
+		"ip_private":          ValidIPPrivate,
+		"ip_public":           ValidIPPublic,
+		"ip_in":               ValidIPIn,
+		"port":                ValidPort,
+		"hostname":            ValidHostname,
+		"fqdn":                ValidFQDN,
+		"host_port":           ValidHostPort,
+		"imei":                ValidIMEI,
+		"hex_color":           ValidHexColor,
+		"css_color":           ValidCSSColor,
+		"semver":              ValidSemver,
+		"digits":              ValidDigits,
+		"digits_between":      ValidDigitsBetween,
+		"regex":               ValidRegex,
+		"isbn10":              ValidISBN10,
+		"isbn13":              ValidISBN13,
+		"json":                ValidJSON,
+		"date":                ValidDate,
+		"date_format":         ValidDateFormat,
+		"timezone":            ValidTimezone,
+		"bool":                ValidBoolean,
+		"min_items":           ValidMinItems,
+		"max_items":           ValidMaxItems,
+		"contains_item":       ValidContainsItem,
+		"each_in":             ValidEachIn,
+		"sorted":              ValidSorted,
+		"in":                  ValidIn,
+		"not_in":              ValidNotIn,
+		"in_ci":               ValidInFold,
+		"not_in_ci":           ValidNotInFold,
+		"contains":            ValidContains,
+		"contains_any":        ValidContainsAny,
+		"excludes":            ValidExcludes,
+		"excludes_rune":       ValidExcludesRune,
+		"starts_with":         ValidStartsWith,
+		"ends_with":           ValidEndsWith,
+		"doesnt_start_with":   ValidDoesntStartWith,
+		"lowercase":           ValidLowercase,
+		"uppercase":           ValidUppercase,
+		"ascii":               ValidASCII,
+		"printable_ascii":     ValidPrintableASCII,
+		"multibyte":           ValidMultibyte,
+		"id_phone":            ValidIndonesianPhoneNumber,
+		"phone":               ValidPhone,
+		"e164":                ValidE164,
+		"id_postal_code":      ValidIndonesianPostalCode,
 	}
 )
 
@@ -100,22 +169,6 @@ func Required(v interface{}, key, rule string, isRequired bool) error {
 	return nil
 }
 
-func ValidEmail(v interface{}, key, rule string, isRequired bool) error {
-	msg := `The %s field should be a valid email address`
-
-	if isEmpty(v) && !isRequired {
-		return nil
-	}
-
-	vs := ToString(v)
-
-	if isEmail(vs) {
-		return nil
-	}
-
-	return fmt.Errorf(msg, key)
-}
-
 func ValidNumeric(v interface{}, key, rule string, isRequired bool) error {
 	msg := `The %s field should be a valid numeric`
 
@@ -123,9 +176,7 @@ func ValidNumeric(v interface{}, key, rule string, isRequired bool) error {
 		return nil
 	}
 
-	vs := ToString(v)
-
-	if isNumeric(vs) {
+	if _, ok := toNumber(v); ok {
 		return nil
 	}
 
@@ -141,28 +192,21 @@ func ValidMin(v interface{}, key, rule string, isRequired bool) error {
 		return nil
 	}
 
-	min := strings.TrimPrefix(rule, "min:")
+	min := ruleParam(rule)
 
-	if !isNumeric(min) {
+	cm, ok := toNumber(min)
+	if !ok {
 		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
 	}
 
-	vs := ToString(v)
-
-	cm, _ := ToInt64(min)
-
-	if isNumeric(vs) {
-		vi, _ := ToInt64(vs)
-
-		if vi < cm {
-			return fmt.Errorf(msgInt, key, min)
-		}
+	vi, isNumber := numberOrLength(v)
 
+	if vi.Cmp(cm) >= 0 {
 		return nil
 	}
 
-	if len(vs) >= int(cm) {
-		return nil
+	if isNumber {
+		return fmt.Errorf(msgInt, key, min)
 	}
 
 	return fmt.Errorf(msgStr, key, min)
@@ -177,96 +221,194 @@ func ValidMax(v interface{}, key, rule string, isRequired bool) error {
 		return nil
 	}
 
-	max := strings.TrimPrefix(rule, "max:")
+	max := ruleParam(rule)
 
-	if !isNumeric(max) {
+	cm, ok := toNumber(max)
+	if !ok {
 		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
 	}
 
-	vs := ToString(v)
-
-	cm, _ := ToInt64(max)
-
-	if isNumeric(vs) {
-		vi, _ := ToInt64(vs)
-		if vi > cm || (isRequired && isEmpty(v)) {
-			return fmt.Errorf(msgInt, key, max)
-		}
+	vi, isNumber := numberOrLength(v)
 
+	if vi.Cmp(cm) <= 0 && !(isRequired && isEmpty(v)) {
 		return nil
 	}
 
-	if len(vs) > int(cm) || (isRequired && isEmpty(v)) {
-		return fmt.Errorf(msgStr, key, max)
+	if isNumber {
+		return fmt.Errorf(msgInt, key, max)
 	}
 
-	return nil
+	return fmt.Errorf(msgStr, key, max)
 }
 
-func ValidAlphaNum(v interface{}, key, rule string, isRequired bool) error {
+func ValidBetween(v interface{}, key, rule string, isRequired bool) error {
+
+	msgInt := `The %s field should be between %s and %s`
+	msgStr := `The %s field should be length between %s and %s`
+
 	if isEmpty(v) && !isRequired {
 		return nil
 	}
 
-	msg := `The %s field should contain: [a-zA-Z0-9]`
+	params := ruleParams(rule)
+	if len(params) != 2 {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
 
-	s := ToString(v)
+	min, okMin := toNumber(params[0])
+	max, okMax := toNumber(params[1])
+	if !okMin || !okMax {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
 
-	if !isAlphaNumeric(s) {
-		return fmt.Errorf(msg, key)
+	vi, isNumber := numberOrLength(v)
+
+	if vi.Cmp(min) >= 0 && vi.Cmp(max) <= 0 {
+		return nil
 	}
 
-	return nil
+	if isNumber {
+		return fmt.Errorf(msgInt, key, params[0], params[1])
+	}
+
+	return fmt.Errorf(msgStr, key, params[0], params[1])
+}
+
+func ValidGt(v interface{}, key, rule string, isRequired bool) error {
+	return compareNumber(v, key, rule, isRequired, `The %s field should be greater than %s`, func(c int) bool {
+		return c > 0
+	})
 }
 
-func ValidAlphaDash(v interface{}, key, rule string, isRequired bool) error {
+func ValidGte(v interface{}, key, rule string, isRequired bool) error {
+	return compareNumber(v, key, rule, isRequired, `The %s field should be greater than or equal %s`, func(c int) bool {
+		return c >= 0
+	})
+}
+
+func ValidLt(v interface{}, key, rule string, isRequired bool) error {
+	return compareNumber(v, key, rule, isRequired, `The %s field should be less than %s`, func(c int) bool {
+		return c < 0
+	})
+}
+
+func ValidLte(v interface{}, key, rule string, isRequired bool) error {
+	return compareNumber(v, key, rule, isRequired, `The %s field should be less than or equal %s`, func(c int) bool {
+		return c <= 0
+	})
+}
+
+// compareNumber compare a numeric value with the rule parameter,
+// accept receives the result of the value compared to the parameter
+func compareNumber(v interface{}, key, rule string, isRequired bool, msg string, accept func(int) bool) error {
 	if isEmpty(v) && !isRequired {
 		return nil
 	}
 
-	msg := `The %s field should contain: [a-zA-Z0-9], underscore (_), dash (-)`
+	param := ruleParam(rule)
 
-	s := ToString(v)
+	cm, ok := toNumber(param)
+	if !ok {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
 
-	if !isAlphaDash(s) {
-		return fmt.Errorf(msg, key)
+	vi, ok := toNumber(v)
+	if !ok {
+		return fmt.Errorf(`The %s field should be a valid numeric`, key)
 	}
 
-	return nil
+	if accept(vi.Cmp(cm)) {
+		return nil
+	}
+
+	return fmt.Errorf(msg, key, param)
+}
+
+func ValidLen(v interface{}, key, rule string, isRequired bool) error {
+	return compareLength(v, key, rule, isRequired, `The %s field should be length %d`, func(n, limit int) bool {
+		return n == limit
+	})
 }
 
-func ValidAlphaSpace(v interface{}, key, rule string, isRequired bool) error {
+func ValidMinLen(v interface{}, key, rule string, isRequired bool) error {
+	return compareLength(v, key, rule, isRequired, `The %s field should be minimum length %d`, func(n, limit int) bool {
+		return n >= limit
+	})
+}
+
+func ValidMaxLen(v interface{}, key, rule string, isRequired bool) error {
+	return compareLength(v, key, rule, isRequired, `The %s field should be maximum length %d`, func(n, limit int) bool {
+		return n <= limit
+	})
+}
+
+// compareLength compare the length of a value with the rule parameter, the
+// optional second parameter "grapheme" counts user-perceived characters
+// instead of runes, e.g. max_len:20,grapheme
+func compareLength(v interface{}, key, rule string, isRequired bool, msg string, accept func(n, limit int) bool) error {
 	if isEmpty(v) && !isRequired {
 		return nil
 	}
 
-	msg := `The %s field should contain: [a-zA-Z0-9], underscore (_), space`
+	params := ruleParams(rule)
+	if len(params) == 0 || len(params) > 2 || (len(params) == 2 && params[1] != "grapheme") {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
 
-	s := ToString(v)
+	limit, err := strconv.Atoi(params[0])
+	if err != nil || limit < 0 {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
 
-	if !isAlphaSpace(s) {
-		return fmt.Errorf(msg, key)
+	n, _ := lengthOf(v, len(params) == 2)
+
+	if accept(n, limit) {
+		return nil
 	}
 
-	return nil
+	return fmt.Errorf(msg, key, limit)
 }
 
-func ValidAlpha(v interface{}, key, rule string, isRequired bool) error {
+// ValidSize check the length of a string or collection is equal to the parameter,
+// or a number is equal to the parameter
+func ValidSize(v interface{}, key, rule string, isRequired bool) error {
+	msgInt := `The %s field should be %s`
+	msgStr := `The %s field should be length %s`
+
 	if isEmpty(v) && !isRequired {
 		return nil
 	}
 
-	msg := `The %s field should contain: [a-zA-Z]`
+	size := ruleParam(rule)
 
-	s := ToString(v)
+	cm, ok := toNumber(size)
+	if !ok {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
 
-	if !isAlpha(s) {
-		return fmt.Errorf(msg, key)
+	// a string, numeric or not, and a collection are measured by their length
+	if n, ok := lengthOf(v, false); ok {
+		if cm.IsInt() && cm.Num().IsInt64() && cm.Num().Int64() == int64(n) {
+			return nil
+		}
+		return fmt.Errorf(msgStr, key, size)
 	}
 
-	return nil
+	vi, isNumber := numberOrLength(v)
+
+	if vi.Cmp(cm) == 0 {
+		return nil
+	}
+
+	if isNumber {
+		return fmt.Errorf(msgInt, key, size)
+	}
+
+	return fmt.Errorf(msgStr, key, size)
 }
 
+// ValidURL check the value is an absolute URL with a host, the optional
+// parameters restrict the schemes, e.g. url:https or url:http,https
 func ValidURL(v interface{}, key, rule string, isRequired bool) error {
 	if isEmpty(v) && !isRequired {
 		return nil
@@ -274,12 +416,16 @@ func ValidURL(v interface{}, key, rule string, isRequired bool) error {
 
 	msg := `The %s field should be valid URL`
 
-	s := ToString(v)
-
-	if !isURL(s) {
+	u, ok := parseURL(ToString(v))
+	if !ok {
 		return fmt.Errorf(msg, key)
 	}
 
+	schemes := ruleParams(rule)
+	if len(schemes) > 0 && !isIn(schemes, strings.ToLower(u.Scheme)) {
+		return fmt.Errorf(`The %s field should be valid URL with scheme %s`, key, strings.Join(schemes, ", "))
+	}
+
 	return nil
 }
 
@@ -315,20 +461,43 @@ func ValidFloat(v interface{}, key, rule string, isRequired bool) error {
 	return nil
 }
 
+// ValidUUID check the value is a UUID in any case, the optional parameters
+// restrict the versions, e.g. uuid:v4,v7. The default "any" accepts every
+// version as well as the nil UUID
 func ValidUUID(v interface{}, key, rule string, isRequired bool) error {
 	if isEmpty(v) && !isRequired {
 		return nil
 	}
 
-	msg := `The %s field should be uuid`
+	var versions []int
+	for _, param := range ruleParams(rule) {
+		if param == "any" {
+			continue
+		}
 
-	s := ToString(v)
+		n, err := strconv.Atoi(strings.TrimPrefix(param, "v"))
+		if err != nil || !strings.HasPrefix(param, "v") || n < 1 || n > 8 {
+			return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+		}
+		versions = append(versions, n)
+	}
 
-	if !isUUID(s) {
-		return fmt.Errorf(msg, key)
+	version, ok := parseUUID(ToString(v))
+	if !ok {
+		return fmt.Errorf(`The %s field should be uuid`, key)
 	}
 
-	return nil
+	if len(versions) == 0 {
+		return nil
+	}
+
+	for _, n := range versions {
+		if version == n {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(`The %s field should be uuid %s`, key, strings.Join(ruleParams(rule), ", "))
 }
 
 func ValidUUID3(v interface{}, key, rule string, isRequired bool) error {
@@ -379,6 +548,8 @@ func ValidUUID5(v interface{}, key, rule string, isRequired bool) error {
 	return nil
 }
 
+// ValidCreditCard check the card number checksum, the optional parameters
+// restrict the accepted brands, e.g. credit_card:visa,mastercard
 func ValidCreditCard(v interface{}, key, rule string, isRequired bool) error {
 	if isEmpty(v) && !isRequired {
 		return nil
@@ -392,6 +563,38 @@ func ValidCreditCard(v interface{}, key, rule string, isRequired bool) error {
 		return fmt.Errorf(msg, key)
 	}
 
+	brands := ruleParams(rule)
+	if len(brands) == 0 {
+		return nil
+	}
+
+	for _, b := range brands {
+		if _, ok := cardBrands[b]; !ok {
+			return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+		}
+	}
+
+	brand, _ := cardBrandOf(stripSeparators(s))
+	if !isIn(brands, brand) {
+		return fmt.Errorf(`The %s field should be %s card number`, key, strings.Join(brands, ", "))
+	}
+
+	return nil
+}
+
+func ValidIBAN(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be valid IBAN`
+
+	s := ToString(v)
+
+	if !isIBAN(s) {
+		return fmt.Errorf(msg, key)
+	}
+
 	return nil
 }
 
@@ -555,70 +758,159 @@ func ValidISBN13(v interface{}, key, rule string, isRequired bool) error {
 	return nil
 }
 
-func ValidJSON(v interface{}, key, rule string, isRequired bool) error {
+func ValidBoolean(v interface{}, key, rule string, isRequired bool) error {
 	if isEmpty(v) && !isRequired {
 		return nil
 	}
 
-	msg := `The %s field should be valid JSON`
+	msg := `The %s field should be valid boolean type`
 
 	s := ToString(v)
 
-	if !isJSON(s) {
+	if !isBoolean(s) {
 		return fmt.Errorf(msg, key)
 	}
 
 	return nil
 }
 
-func ValidBoolean(v interface{}, key, rule string, isRequired bool) error {
+func ValidIn(v interface{}, key, rule string, isRequired bool) error {
 	if isEmpty(v) && !isRequired {
 		return nil
 	}
 
-	msg := `The %s field should be valid boolean type`
+	contain := ruleParam(rule)
+
+	haystack := ruleParams(rule)
+
+	msg := `The %s field should be contain in: %s`
 
 	s := ToString(v)
 
-	if !isBoolean(s) {
-		return fmt.Errorf(msg, key)
+	if !isIn(haystack, s) {
+		return fmt.Errorf(msg, key, contain)
 	}
 
 	return nil
 }
 
-func ValidIn(v interface{}, key, rule string, isRequired bool) error {
+func ValidIndonesianPhoneNumber(v interface{}, key, rule string, isRequired bool) error {
 	if isEmpty(v) && !isRequired {
 		return nil
 	}
 
-	contain := strings.Trim(rule, "in:")
+	msg := `The %s field should be valid mobile phone number`
 
-	haystack := strings.Split(contain, ",")
+	s := ToString(v)
 
-	msg := `The %s field should be contain in: %s`
+	if !isIndonesiaPhoneNumber(s) {
+		return fmt.Errorf(msg, key)
+	}
+
+	return nil
+}
+
+func ValidCSSColor(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be valid css color`
 
 	s := ToString(v)
 
-	if !isIn(haystack, s) {
-		return fmt.Errorf(msg, key, contain)
+	if !isCSSColor(s) {
+		return fmt.Errorf(msg, key)
 	}
 
 	return nil
 }
 
-func ValidIndonesianPhoneNumber(v interface{}, key, rule string, isRequired bool) error {
+func ValidSemver(v interface{}, key, rule string, isRequired bool) error {
 	if isEmpty(v) && !isRequired {
 		return nil
 	}
 
-	msg := `The %s field should valid mobile phone number`
+	msg := `The %s field should be valid semantic version`
 
 	s := ToString(v)
 
-	if !isIndonesiaPhoneNumber(s) {
+	if !isSemver(s) {
 		return fmt.Errorf(msg, key)
 	}
 
 	return nil
 }
+
+// ValidDigits check the value contains digits only, digits:N also
+// requires exactly N digits
+func ValidDigits(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	s := ToString(v)
+
+	param := ruleParam(rule)
+	if param == "" {
+		if !isDigits(s) {
+			return fmt.Errorf(`The %s field should contain digits only`, key)
+		}
+		return nil
+	}
+
+	n, err := strconv.Atoi(param)
+	if err != nil || n < 1 {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	if !isDigits(s) || len(s) != n {
+		return fmt.Errorf(`The %s field should be %d digits`, key, n)
+	}
+
+	return nil
+}
+
+func ValidDigitsBetween(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	params := ruleParams(rule)
+	if len(params) != 2 {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	min, errMin := strconv.Atoi(params[0])
+	max, errMax := strconv.Atoi(params[1])
+	if errMin != nil || errMax != nil || min < 1 || min > max {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	s := ToString(v)
+
+	if !isDigits(s) || len(s) < min || len(s) > max {
+		return fmt.Errorf(`The %s field should be between %d and %d digits`, key, min, max)
+	}
+
+	return nil
+}
+
+// ValidRegex match the value with the pattern of the rule, e.g. regex:^[a-z]+$
+// a pipe inside the pattern must be escaped to not end the rule, e.g. `valid:"regex:^(cat\\|dog)$"`
+func ValidRegex(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	rx, err := compileRegex(ruleParam(rule))
+	if err != nil {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	if !rx.MatchString(ToString(v)) {
+		return fmt.Errorf(`The %s field has invalid format value`, key)
+	}
+
+	return nil
+}
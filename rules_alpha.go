@@ -0,0 +1,201 @@
+// Package validator
+package validator
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// alphaClass are the characters accepted by an alpha rule beside the letters
+type alphaClass struct {
+	digits bool // numbers
+	dash   bool // underscore and dash
+	space  bool
+}
+
+// alphaScripts are the letters accepted by each script parameter of the alpha rules
+var alphaScripts = map[string]func(r rune) bool{
+	"ascii": func(r rune) bool {
+		return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+	},
+	"latin": func(r rune) bool {
+		return unicode.Is(unicode.Latin, r)
+	},
+	"unicode": unicode.IsLetter,
+}
+
+// isAlphaClass check the input only contains the letters of the script and the
+// characters of the class, after normalization to NFC. At most maxMarks combining
+// marks are accepted after a letter, except for the ascii script, as the letters
+// of the scripts like Javanese carry their vowels as marks
+func isAlphaClass(str, script string, class alphaClass) bool {
+	letter := alphaScripts[script]
+	marks := -1 // combining marks since the last letter, -1 when not after a letter
+
+	for _, r := range normalizeNFC(str) {
+		ok := false
+		switch {
+		case letter(r):
+			ok = true
+		case unicode.Is(unicode.M, r):
+			ok = script != "ascii" && marks >= 0 && marks < maxMarks
+		case class.digits && r >= '0' && r <= '9':
+			ok = true
+		case class.digits && script == "unicode" && unicode.IsDigit(r):
+			ok = true
+		case class.dash && (r == '_' || r == '-'):
+			ok = true
+		case class.space && r == ' ':
+			ok = true
+		}
+
+		if !ok {
+			return false
+		}
+
+		switch {
+		case letter(r):
+			marks = 0
+		case unicode.Is(unicode.M, r):
+			marks++
+		default:
+			marks = -1
+		}
+	}
+
+	return str != ""
+}
+
+// alphaClassDescription describe the accepted characters in the error message
+func alphaClassDescription(script string, class alphaClass) string {
+	desc := ""
+	switch script {
+	case "ascii":
+		desc = "[a-zA-Z]"
+		if class.digits {
+			desc = "[a-zA-Z0-9]"
+		}
+	case "latin":
+		desc = "latin letters"
+	case "unicode":
+		desc = "letters"
+	}
+
+	if class.digits && script != "ascii" {
+		desc += ", numbers"
+	}
+	if class.dash {
+		desc += ", underscore (_), dash (-)"
+	}
+	if class.space {
+		desc += ", space"
+	}
+
+	return desc
+}
+
+// validAlpha run an alpha rule, the optional parameter is the script of the
+// letters: ascii (default), latin or unicode, e.g. alpha_space:unicode
+func validAlpha(v interface{}, key, rule string, isRequired bool, class alphaClass) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	script := ruleParam(rule)
+	if script == "" {
+		script = "ascii"
+	}
+
+	if _, ok := alphaScripts[script]; !ok {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	if !isAlphaClass(ToString(v), script, class) {
+		return fmt.Errorf(`The %s field should contain: %s`, key, alphaClassDescription(script, class))
+	}
+
+	return nil
+}
+
+func ValidAlpha(v interface{}, key, rule string, isRequired bool) error {
+	return validAlpha(v, key, rule, isRequired, alphaClass{})
+}
+
+func ValidAlphaNum(v interface{}, key, rule string, isRequired bool) error {
+	return validAlpha(v, key, rule, isRequired, alphaClass{digits: true})
+}
+
+func ValidAlphaDash(v interface{}, key, rule string, isRequired bool) error {
+	return validAlpha(v, key, rule, isRequired, alphaClass{digits: true, dash: true})
+}
+
+func ValidAlphaSpace(v interface{}, key, rule string, isRequired bool) error {
+	return validAlpha(v, key, rule, isRequired, alphaClass{digits: true, dash: true, space: true})
+}
+
+// isPersonName check the input is a person name in any script: words of letters
+// separated by a single space, apostrophe or hyphen, like "Siti Nurhaliza binti Ömer",
+// "O'Brien" or "Jean-Luc". A word may end with a period for the initials, like "J. R. R. Tolkien".
+// The input is normalized to NFC, at most maxMarks combining marks may follow a letter
+func isPersonName(str string) bool {
+	const (
+		start = iota
+		letter
+		space
+		joiner
+		period
+	)
+
+	prev := start
+	marks := 0
+	for _, r := range normalizeNFC(str) {
+		switch {
+		case unicode.IsLetter(r):
+			if prev == period {
+				return false
+			}
+			prev = letter
+			marks = 0
+		case unicode.Is(unicode.M, r):
+			if prev != letter || marks == maxMarks {
+				return false
+			}
+			marks++
+		case r == ' ':
+			if prev != letter && prev != period {
+				return false
+			}
+			prev = space
+		case r == '\'' || r == '’' || r == '-':
+			if prev != letter {
+				return false
+			}
+			prev = joiner
+		case r == '.':
+			if prev != letter {
+				return false
+			}
+			prev = period
+		default:
+			return false
+		}
+	}
+
+	return prev == letter || prev == period
+}
+
+func ValidPersonName(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be valid person name`
+
+	s := ToString(v)
+
+	if !isPersonName(s) {
+		return fmt.Errorf(msg, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,268 @@
+// Package validator
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// collectionItems return the elements of a slice or an array
+func collectionItems(i interface{}) ([]interface{}, bool) {
+	v := reflect.ValueOf(indirect(i))
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	items := make([]interface{}, v.Len())
+	for j := range items {
+		items[j] = v.Index(j).Interface()
+	}
+
+	return items, true
+}
+
+// itemKey return the field of a struct item, by its Go name, tag field name or error bag name,
+// or the key of a map item, used by unique:field
+func itemKey(vl *Validator, item interface{}, name string) (interface{}, bool) {
+	v := reflect.ValueOf(indirect(item))
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return structLookup(vl, v)(name)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		mv := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		if !mv.IsValid() {
+			return nil, true
+		}
+		return mv.Interface(), true
+	}
+
+	return nil, false
+}
+
+// duplicateItems return the pairs of indices {first, duplicate} of the equal items
+func duplicateItems(items []interface{}) [][2]int {
+	var dups [][2]int
+
+	seen := map[interface{}]int{}
+	for j, item := range items {
+		v := reflect.ValueOf(item)
+		if item != nil && !v.Comparable() {
+			for k := 0; k < j; k++ {
+				if reflect.DeepEqual(items[k], item) {
+					dups = append(dups, [2]int{k, j})
+					break
+				}
+			}
+			continue
+		}
+
+		if k, ok := seen[item]; ok {
+			dups = append(dups, [2]int{k, j})
+			continue
+		}
+		seen[item] = j
+	}
+
+	return dups
+}
+
+// compareItems compare two items of a sorted list: numbers, strings and times
+func compareItems(a, b interface{}) (int, bool) {
+	if ta, ok := indirect(a).(time.Time); ok {
+		tb, ok := indirect(b).(time.Time)
+		if !ok {
+			return 0, false
+		}
+		return ta.Compare(tb), true
+	}
+
+	switch reflect.ValueOf(indirect(a)).Kind() {
+	case reflect.String:
+		sb, ok := indirect(b).(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(indirect(a).(string), sb), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		na, _ := toNumber(a)
+		nb, ok := toNumber(b)
+		if !ok {
+			return 0, false
+		}
+		return na.Cmp(nb), true
+	}
+
+	return 0, false
+}
+
+// ValidUnique check the items of the list are unique, or the given field of
+// the struct items or key of the map items with the parameter, e.g. unique:sku
+func ValidUnique(ctx *ruleContext, v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	items, ok := collectionItems(v)
+	if !ok {
+		return fmt.Errorf(`The %s field should be a list`, key)
+	}
+
+	if field := ruleParam(rule); field != "" {
+		keys := make([]interface{}, len(items))
+		for j, item := range items {
+			if keys[j], ok = itemKey(ctx.validator, item, field); !ok {
+				return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+			}
+		}
+		items = keys
+	}
+
+	dups := duplicateItems(items)
+	if len(dups) == 0 {
+		return nil
+	}
+
+	pairs := make([]string, len(dups))
+	for j, d := range dups {
+		pairs[j] = fmt.Sprintf("%d and %d", d[0], d[1])
+	}
+
+	return fmt.Errorf(`The %s field should have unique items, duplicates at index: %s`, key, strings.Join(pairs, ", "))
+}
+
+func ValidMinItems(v interface{}, key, rule string, isRequired bool) error {
+	return validItemsCount(v, key, rule, isRequired, `The %s field should have at least %d items`, func(l, n int) bool {
+		return l >= n
+	})
+}
+
+func ValidMaxItems(v interface{}, key, rule string, isRequired bool) error {
+	return validItemsCount(v, key, rule, isRequired, `The %s field should have at most %d items`, func(l, n int) bool {
+		return l <= n
+	})
+}
+
+// validItemsCount check the number of items of a slice, an array or a map
+func validItemsCount(v interface{}, key, rule string, isRequired bool, msg string, check func(l, n int) bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	n, err := strconv.Atoi(ruleParam(rule))
+	if err != nil || n < 0 {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	rv := reflect.ValueOf(indirect(v))
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return fmt.Errorf(`The %s field should be a list`, key)
+	}
+
+	if !check(rv.Len(), n) {
+		return fmt.Errorf(msg, key, n)
+	}
+
+	return nil
+}
+
+// ValidContainsItem check one of the items of the list is the parameter, e.g. contains_item:admin
+func ValidContainsItem(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	param := ruleParam(rule)
+	if param == "" {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	items, ok := collectionItems(v)
+	if !ok {
+		return fmt.Errorf(`The %s field should be a list`, key)
+	}
+
+	for _, item := range items {
+		if ToString(indirect(item)) == param {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(`The %s field should contain item %s`, key, param)
+}
+
+// ValidEachIn check every item of the list is one of the parameters, e.g. each_in:read,write
+func ValidEachIn(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	params := ruleParams(rule)
+	if len(params) == 0 {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	items, ok := collectionItems(v)
+	if !ok {
+		return fmt.Errorf(`The %s field should be a list`, key)
+	}
+
+	var invalid []string
+	for j, item := range items {
+		if !isIn(params, ToString(indirect(item))) {
+			invalid = append(invalid, strconv.Itoa(j))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf(`The %s field should only contain items in: %s, invalid at index: %s`, key, strings.Join(params, ","), strings.Join(invalid, ", "))
+	}
+
+	return nil
+}
+
+// ValidSorted check the items of the list are in ascending order, or descending
+// order with the desc parameter, e.g. sorted:desc
+func ValidSorted(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	desc := false
+	switch ruleParam(rule) {
+	case "", "asc":
+	case "desc":
+		desc = true
+	default:
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	items, ok := collectionItems(v)
+	if !ok {
+		return fmt.Errorf(`The %s field should be a list`, key)
+	}
+
+	msg := `The %s field should be sorted in ascending order`
+	if desc {
+		msg = `The %s field should be sorted in descending order`
+	}
+
+	for j := 1; j < len(items); j++ {
+		c, ok := compareItems(items[j-1], items[j])
+		if !ok || (!desc && c > 0) || (desc && c < 0) {
+			return fmt.Errorf(msg, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+// Package validator
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the layouts accepted when a date is given as a string
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+	"02-01-2006",
+	"02/01/2006",
+}
+
+// toTime convert a time.Time, *time.Time or a date string into a time, a string
+// without zone is in the given location
+func toTime(i interface{}, loc *time.Location) (time.Time, bool) {
+	switch v := indirect(i).(type) {
+	case time.Time:
+		return v, true
+	case string:
+		return parseDate(v, loc)
+	}
+
+	return time.Time{}, false
+}
+
+// parseDate parse the string with the first matching layout of dateLayouts,
+// the layouts without zone are parsed in the given location
+func parseDate(str string, loc *time.Location) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, str, loc); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// dateLocation return the location of the clock, the dates without zone are
+// in this location so they compare with today as the clock sees it
+func dateLocation(ctx *ruleContext) *time.Location {
+	return ctx.validator.Clock().Location()
+}
+
+// resolveDate resolve the parameter of a date rule: a keyword (now, today,
+// tomorrow, yesterday), a date literal or the name of a sibling field.
+// The second result is false when the parameter refers to an empty sibling
+func resolveDate(ctx *ruleContext, param string) (time.Time, bool, error) {
+	now := ctx.validator.Clock()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch param {
+	case "now":
+		return now, true, nil
+	case "today":
+		return today, true, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true, nil
+	}
+
+	if t, ok := parseDate(param, now.Location()); ok {
+		return t, true, nil
+	}
+
+	other, ok := ctx.lookup(param)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("unknown date %s", param)
+	}
+
+	if isEmpty(other) {
+		return time.Time{}, false, nil
+	}
+
+	t, ok := toTime(other, now.Location())
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("%s is not a date", param)
+	}
+
+	return t, true, nil
+}
+
+func ValidDate(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be a valid date`
+
+	if _, ok := toTime(v, time.UTC); !ok {
+		return fmt.Errorf(msg, key)
+	}
+
+	return nil
+}
+
+func ValidDateFormat(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should match the date format %s`
+
+	layout := ruleParam(rule)
+	if layout == "" {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	switch val := indirect(v).(type) {
+	case time.Time:
+		return nil
+	case string:
+		if _, err := time.Parse(layout, val); err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(msg, key, layout)
+}
+
+func ValidBefore(ctx *ruleContext, v interface{}, key, rule string, isRequired bool) error {
+	return compareDate(ctx, v, key, rule, isRequired, `The %s field should be a date before %s`, func(t, ref time.Time) bool {
+		return t.Before(ref)
+	})
+}
+
+func ValidBeforeOrEqual(ctx *ruleContext, v interface{}, key, rule string, isRequired bool) error {
+	return compareDate(ctx, v, key, rule, isRequired, `The %s field should be a date before or equal %s`, func(t, ref time.Time) bool {
+		return !t.After(ref)
+	})
+}
+
+func ValidAfter(ctx *ruleContext, v interface{}, key, rule string, isRequired bool) error {
+	return compareDate(ctx, v, key, rule, isRequired, `The %s field should be a date after %s`, func(t, ref time.Time) bool {
+		return t.After(ref)
+	})
+}
+
+func ValidAfterOrEqual(ctx *ruleContext, v interface{}, key, rule string, isRequired bool) error {
+	return compareDate(ctx, v, key, rule, isRequired, `The %s field should be a date after or equal %s`, func(t, ref time.Time) bool {
+		return !t.Before(ref)
+	})
+}
+
+// compareDate compare the date value with the date resolved from the rule parameter,
+// the rule passes when the parameter refers to an empty sibling field
+func compareDate(ctx *ruleContext, v interface{}, key, rule string, isRequired bool, msg string, accept func(t, ref time.Time) bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	param := ruleParam(rule)
+
+	ref, ok, err := resolveDate(ctx, param)
+	if err != nil {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	t, isDate := toTime(v, dateLocation(ctx))
+	if !isDate {
+		return fmt.Errorf(`The %s field should be a valid date`, key)
+	}
+
+	if !ok || accept(t, ref) {
+		return nil
+	}
+
+	return fmt.Errorf(msg, key, param)
+}
+
+// ValidDateBetween check the date is between the two parameters, both inclusive
+func ValidDateBetween(ctx *ruleContext, v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be a date between %s and %s`
+
+	params := ruleParams(rule)
+	if len(params) != 2 {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	start, okStart, err := resolveDate(ctx, params[0])
+	if err != nil {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	end, okEnd, err := resolveDate(ctx, params[1])
+	if err != nil {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	t, ok := toTime(v, dateLocation(ctx))
+	if !ok {
+		return fmt.Errorf(`The %s field should be a valid date`, key)
+	}
+
+	if (okStart && t.Before(start)) || (okEnd && t.After(end)) {
+		return fmt.Errorf(msg, key, params[0], params[1])
+	}
+
+	return nil
+}
+
+// ValidTimezone check the value is an IANA time zone name like Asia/Jakarta,
+// it relies on the time zone database of the system unless time/tzdata is imported
+func ValidTimezone(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be a valid timezone`
+
+	s := ToString(v)
+
+	if s == "" || strings.EqualFold(s, "local") {
+		return fmt.Errorf(msg, key)
+	}
+
+	if _, err := time.LoadLocation(s); err != nil {
+		return fmt.Errorf(msg, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+// Package validator
+package validator
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mxLookupTimeout bounds the MX lookup of the email_mx rule
+const mxLookupTimeout = 5 * time.Second
+
+// Resolver looks up the MX records of a domain, *net.Resolver satisfies it
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+//go:embed disposable_domains.txt
+var disposableDomainsFile string
+
+var (
+	disposableDomains     map[string]bool
+	disposableDomainsOnce sync.Once
+	disposableDomainsMu   sync.RWMutex
+)
+
+// loadDisposableDomains read the embedded disposable domains list once
+func loadDisposableDomains() {
+	disposableDomainsOnce.Do(func() {
+		disposableDomains = map[string]bool{}
+		sc := bufio.NewScanner(strings.NewReader(disposableDomainsFile))
+		for sc.Scan() {
+			line := strings.TrimSpace(sc.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			disposableDomains[strings.ToLower(line)] = true
+		}
+	})
+}
+
+// AddDisposableDomains extend the disposable domains rejected by email:no_disposable
+func AddDisposableDomains(domains ...string) {
+	loadDisposableDomains()
+
+	disposableDomainsMu.Lock()
+	defer disposableDomainsMu.Unlock()
+
+	for _, d := range domains {
+		disposableDomains[toASCIIDomain(strings.TrimSpace(d))] = true
+	}
+}
+
+// isDisposableDomain check the domain or one of its parent domains is listed as disposable
+func isDisposableDomain(domain string) bool {
+	loadDisposableDomains()
+
+	disposableDomainsMu.RLock()
+	defer disposableDomainsMu.RUnlock()
+
+	for {
+		if disposableDomains[domain] {
+			return true
+		}
+
+		i := strings.Index(domain, ".")
+		if i < 0 {
+			return false
+		}
+		domain = domain[i+1:]
+	}
+}
+
+// parseEmail parse a bare RFC 5322 address, without display name or angle brackets,
+// and return its domain converted to ASCII
+func parseEmail(str string) (string, bool) {
+	if len(str) > 254 {
+		return "", false
+	}
+
+	addr, err := mail.ParseAddress(str)
+	if err != nil || addr.Name != "" {
+		return "", false
+	}
+
+	// net/mail unquotes the needless quotes of the local part, an address
+	// written without quotes must come back unchanged
+	if !strings.HasPrefix(str, `"`) && addr.Address != str {
+		return "", false
+	}
+
+	at := strings.LastIndex(addr.Address, "@")
+	local, domain := addr.Address[:at], addr.Address[at+1:]
+
+	if len(local) > 64 {
+		return "", false
+	}
+
+	domain = toASCIIDomain(domain)
+	if !isFQDN(domain) || strings.HasSuffix(domain, ".") {
+		return "", false
+	}
+
+	return domain, true
+}
+
+// isEmail check a email is valid or not
+func isEmail(email string) bool {
+	_, ok := parseEmail(email)
+	return ok
+}
+
+// ValidEmail check the value is an email address, internationalized domains are accepted.
+// The optional parameters are "strict" to reject quoted local parts and "no_disposable"
+// to reject the disposable email providers, e.g. email:strict,no_disposable
+func ValidEmail(v interface{}, key, rule string, isRequired bool) error {
+	msg := `The %s field should be a valid email address`
+
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	strict, noDisposable := false, false
+	for _, param := range ruleParams(rule) {
+		switch param {
+		case "strict":
+			strict = true
+		case "no_disposable":
+			noDisposable = true
+		default:
+			return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+		}
+	}
+
+	vs := ToString(v)
+
+	domain, ok := parseEmail(vs)
+	if !ok {
+		return fmt.Errorf(msg, key)
+	}
+
+	if strict && strings.HasPrefix(vs, `"`) {
+		return fmt.Errorf(msg, key)
+	}
+
+	if noDisposable && isDisposableDomain(domain) {
+		return fmt.Errorf(`The %s field should not be a disposable email address`, key)
+	}
+
+	return nil
+}
+
+// ValidEmailDomainIn check the domain of the email is one of the parameters,
+// e.g. email_domain_in:example.com,*.example.org
+func ValidEmailDomainIn(v interface{}, key, rule string, isRequired bool) error {
+	return validEmailDomain(v, key, rule, isRequired, true, `The %s field should be email address with domain in: %s`)
+}
+
+// ValidEmailDomainNotIn check the domain of the email is none of the parameters
+func ValidEmailDomainNotIn(v interface{}, key, rule string, isRequired bool) error {
+	return validEmailDomain(v, key, rule, isRequired, false, `The %s field should not be email address with domain in: %s`)
+}
+
+func validEmailDomain(v interface{}, key, rule string, isRequired, allow bool, msg string) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	domains := ruleParams(rule)
+	if len(domains) == 0 {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	domain, ok := parseEmail(ToString(v))
+	if !ok {
+		return fmt.Errorf(`The %s field should be a valid email address`, key)
+	}
+
+	matched := false
+	for _, d := range domains {
+		if matchHost(domain, toASCIIDomain(d)) {
+			matched = true
+			break
+		}
+	}
+
+	if matched != allow {
+		return fmt.Errorf(msg, key, strings.Join(domains, ", "))
+	}
+
+	return nil
+}
+
+// ValidEmailMX check the domain of the email accepts mails by looking up its MX
+// records with the resolver of the validator, a null MX (RFC 7505) is rejected
+func ValidEmailMX(ctx *ruleContext, v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be email address with a domain accepting mails`
+
+	domain, ok := parseEmail(ToString(v))
+	if !ok {
+		return fmt.Errorf(`The %s field should be a valid email address`, key)
+	}
+
+	c, cancel := context.WithTimeout(context.Background(), mxLookupTimeout)
+	defer cancel()
+
+	records, err := ctx.validator.Resolver.LookupMX(c, domain)
+	if err != nil {
+		return fmt.Errorf(msg, key)
+	}
+
+	for _, mx := range records {
+		if mx.Host != "." && mx.Host != "" {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(msg, key)
+}
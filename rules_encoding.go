@@ -0,0 +1,267 @@
+// Package validator
+package validator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// crockfordBase32 is the alphabet of the ULID, without I, L, O and U
+	crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// base62 is the alphabet of the KSUID, in ASCII order
+	base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// maxKSUID is the largest KSUID, its 20 bytes all set
+	maxKSUID = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+)
+
+// decodeBase64 decode the string with the encoding, the line breaks ignored
+// by encoding/base64 are rejected as well as the non zero padding bits
+func decodeBase64(enc *base64.Encoding, str string) ([]byte, bool) {
+	if strings.ContainsAny(str, "\r\n") {
+		return nil, false
+	}
+
+	b, err := enc.Strict().DecodeString(str)
+	if err != nil {
+		return nil, false
+	}
+
+	return b, true
+}
+
+// isBase64 check the provided string is standard base64 with padding
+func isBase64(str string) bool {
+	_, ok := decodeBase64(base64.StdEncoding, str)
+	return ok
+}
+
+// isBase64URL check the provided string is URL safe base64, with or without padding
+func isBase64URL(str string) bool {
+	enc := base64.RawURLEncoding
+	if strings.HasSuffix(str, "=") {
+		enc = base64.URLEncoding
+	}
+
+	_, ok := decodeBase64(enc, str)
+	return ok
+}
+
+// isBase64Raw check the provided string is standard base64 without padding
+func isBase64Raw(str string) bool {
+	_, ok := decodeBase64(base64.RawStdEncoding, str)
+	return ok
+}
+
+// isHex check the provided string is hex encoded bytes, an even number of hexadecimal digits
+func isHex(str string) bool {
+	if len(str)%2 != 0 {
+		return false
+	}
+
+	for i := 0; i < len(str); i++ {
+		if !isHexDigit(str[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isJWT check the provided string is a JSON Web Token in the compact serialization:
+// a JSON object header having an algorithm, a JSON object claims set and a
+// signature, all base64url encoded without padding. The signature is not verified
+func isJWT(str string) bool {
+	parts := strings.Split(str, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	var claims map[string]interface{}
+
+	b, ok := decodeBase64(base64.RawURLEncoding, parts[0])
+	if !ok || json.Unmarshal(b, &header) != nil || header.Alg == "" {
+		return false
+	}
+
+	b, ok = decodeBase64(base64.RawURLEncoding, parts[1])
+	if !ok || json.Unmarshal(b, &claims) != nil || claims == nil {
+		return false
+	}
+
+	_, ok = decodeBase64(base64.RawURLEncoding, parts[2])
+	return ok
+}
+
+// isULID check the provided string is a ULID: 26 characters of Crockford's base32
+// in any case, the first one being at most 7 as the value is 128 bits
+func isULID(str string) bool {
+	if len(str) != 26 || str[0] > '7' {
+		return false
+	}
+
+	return strings.Trim(strings.ToUpper(str), crockfordBase32) == ""
+}
+
+// isKSUID check the provided string is a KSUID: 27 characters of base62 not
+// exceeding the largest 160 bits value
+func isKSUID(str string) bool {
+	if len(str) != 27 || strings.Trim(str, base62) != "" {
+		return false
+	}
+
+	return str <= maxKSUID
+}
+
+// parseDataURI parse a data URI as defined by RFC 2397, data:[<mediatype>][;base64],<data>,
+// and return its media type, text/plain when omitted
+func parseDataURI(str string) (string, bool) {
+	if len(str) < 5 || !strings.EqualFold(str[:5], "data:") {
+		return "", false
+	}
+
+	i := strings.Index(str, ",")
+	if i < 0 {
+		return "", false
+	}
+	meta, data := str[5:i], str[i+1:]
+
+	isBase64 := false
+	if strings.HasSuffix(strings.ToLower(meta), ";base64") {
+		meta, isBase64 = meta[:len(meta)-len(";base64")], true
+	}
+
+	mediaType := "text/plain"
+	if meta != "" && !strings.HasPrefix(meta, ";") {
+		mt, _, err := mime.ParseMediaType(meta)
+		if err != nil || !strings.Contains(mt, "/") {
+			return "", false
+		}
+		mediaType = mt
+	}
+
+	if isBase64 {
+		if _, ok := decodeBase64(base64.StdEncoding, data); !ok {
+			return "", false
+		}
+	} else if _, err := url.PathUnescape(data); err != nil {
+		return "", false
+	}
+
+	return mediaType, true
+}
+
+// matchMediaType check the media type matches the pattern, image/* matches every image type
+func matchMediaType(mediaType, pattern string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+	if strings.HasSuffix(pattern, "/*") {
+		return strings.HasPrefix(mediaType, pattern[:len(pattern)-1])
+	}
+
+	return mediaType == pattern
+}
+
+func ValidBase64(v interface{}, key, rule string, isRequired bool) error {
+	return validEncoding(v, key, isRequired, `The %s field should be valid base64 string`, isBase64)
+}
+
+func ValidBase64URL(v interface{}, key, rule string, isRequired bool) error {
+	return validEncoding(v, key, isRequired, `The %s field should be valid base64url string`, isBase64URL)
+}
+
+func ValidBase64Raw(v interface{}, key, rule string, isRequired bool) error {
+	return validEncoding(v, key, isRequired, `The %s field should be valid base64 string without padding`, isBase64Raw)
+}
+
+func ValidHex(v interface{}, key, rule string, isRequired bool) error {
+	return validEncoding(v, key, isRequired, `The %s field should be valid hex string`, isHex)
+}
+
+func ValidJWT(v interface{}, key, rule string, isRequired bool) error {
+	return validEncoding(v, key, isRequired, `The %s field should be valid JWT`, isJWT)
+}
+
+func ValidULID(v interface{}, key, rule string, isRequired bool) error {
+	return validEncoding(v, key, isRequired, `The %s field should be valid ULID`, isULID)
+}
+
+func ValidKSUID(v interface{}, key, rule string, isRequired bool) error {
+	return validEncoding(v, key, isRequired, `The %s field should be valid KSUID`, isKSUID)
+}
+
+// validEncoding run the check on the string value of an encoding rule
+func validEncoding(v interface{}, key string, isRequired bool, msg string, check func(string) bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	if !check(ToString(v)) {
+		return fmt.Errorf(msg, key)
+	}
+
+	return nil
+}
+
+// ValidHexadecimal check the value is hex encoded bytes, the optional parameter
+// is the number of bytes, e.g. hexadecimal:32 for a SHA-256 digest
+func ValidHexadecimal(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	size := -1
+	if param := ruleParam(rule); param != "" {
+		n, err := strconv.Atoi(param)
+		if err != nil || n < 1 {
+			return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+		}
+		size = n
+	}
+
+	s := ToString(v)
+
+	if !isHex(s) {
+		return fmt.Errorf(`The %s field should be valid hex string`, key)
+	}
+
+	if size > 0 && len(s) != size*2 {
+		return fmt.Errorf(`The %s field should be hex string of %d bytes`, key, size)
+	}
+
+	return nil
+}
+
+// ValidDataURI check the value is a data URI, the optional parameters restrict
+// the media types, e.g. data_uri:image/png,image/jpeg or data_uri:image/*
+func ValidDataURI(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	mediaType, ok := parseDataURI(ToString(v))
+	if !ok {
+		return fmt.Errorf(`The %s field should be valid data URI`, key)
+	}
+
+	types := ruleParams(rule)
+	if len(types) == 0 {
+		return nil
+	}
+
+	for _, t := range types {
+		if matchMediaType(mediaType, t) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(`The %s field should be data URI of type: %s`, key, strings.Join(types, ", "))
+}
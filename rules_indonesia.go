@@ -0,0 +1,293 @@
+// Package validator
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// idProvinces are the province codes opening a NIK or a Kartu Keluarga number
+var idProvinces = map[string]string{
+	"11": "Aceh",
+	"12": "Sumatera Utara",
+	"13": "Sumatera Barat",
+	"14": "Riau",
+	"15": "Jambi",
+	"16": "Sumatera Selatan",
+	"17": "Bengkulu",
+	"18": "Lampung",
+	"19": "Kepulauan Bangka Belitung",
+	"21": "Kepulauan Riau",
+	"31": "DKI Jakarta",
+	"32": "Jawa Barat",
+	"33": "Jawa Tengah",
+	"34": "DI Yogyakarta",
+	"35": "Jawa Timur",
+	"36": "Banten",
+	"51": "Bali",
+	"52": "Nusa Tenggara Barat",
+	"53": "Nusa Tenggara Timur",
+	"61": "Kalimantan Barat",
+	"62": "Kalimantan Tengah",
+	"63": "Kalimantan Selatan",
+	"64": "Kalimantan Timur",
+	"65": "Kalimantan Utara",
+	"71": "Sulawesi Utara",
+	"72": "Sulawesi Tengah",
+	"73": "Sulawesi Selatan",
+	"74": "Sulawesi Tenggara",
+	"75": "Gorontalo",
+	"76": "Sulawesi Barat",
+	"81": "Maluku",
+	"82": "Maluku Utara",
+	"91": "Papua",
+	"92": "Papua Barat",
+	"93": "Papua Selatan",
+	"94": "Papua Tengah",
+	"95": "Papua Pegunungan",
+	"96": "Papua Barat Daya",
+}
+
+// idOperators maps the mobile number prefix, without the leading 0, to its operator
+var idOperators = map[string]string{
+	"811": "Telkomsel", "812": "Telkomsel", "813": "Telkomsel",
+	"821": "Telkomsel", "822": "Telkomsel", "823": "Telkomsel",
+	"851": "Telkomsel", "852": "Telkomsel", "853": "Telkomsel",
+	"814": "Indosat", "815": "Indosat", "816": "Indosat",
+	"855": "Indosat", "856": "Indosat", "857": "Indosat", "858": "Indosat",
+	"817": "XL", "818": "XL", "819": "XL", "859": "XL", "877": "XL", "878": "XL",
+	"831": "Axis", "832": "Axis", "833": "Axis", "838": "Axis",
+	"895": "Tri", "896": "Tri", "897": "Tri", "898": "Tri", "899": "Tri",
+	"881": "Smartfren", "882": "Smartfren", "883": "Smartfren", "884": "Smartfren",
+	"885": "Smartfren", "886": "Smartfren", "887": "Smartfren", "888": "Smartfren", "889": "Smartfren",
+}
+
+// NIK holds the data encoded in a Nomor Induk Kependudukan
+type NIK struct {
+	Province  string
+	Regency   string
+	District  string
+	BirthDate time.Time
+	Female    bool
+	Serial    string
+}
+
+// ParseNIK decode a 16 digits NIK: province, regency and district codes,
+// birth date as DDMMYY where women have 40 added to the day, and a serial number.
+// The century of the birth year is taken from the current date
+func ParseNIK(nik string) (NIK, error) {
+	return parseNIK(nik, time.Now())
+}
+
+// parseNIK decode a NIK, the birth years after the year of now belong to the previous century
+func parseNIK(nik string, now time.Time) (NIK, error) {
+	area, date, serial, err := splitIDNumber(nik)
+	if err != nil {
+		return NIK{}, err
+	}
+
+	day := date[0]
+	female := day > 40
+	if female {
+		day -= 40
+	}
+
+	birth, ok := idDate(day, date[1], date[2], now)
+	if !ok {
+		return NIK{}, fmt.Errorf("invalid birth date")
+	}
+
+	return NIK{
+		Province:  area[:2],
+		Regency:   area[2:4],
+		District:  area[4:],
+		BirthDate: birth,
+		Female:    female,
+		Serial:    serial,
+	}, nil
+}
+
+// splitIDNumber split the 16 digits of a NIK or Kartu Keluarga number into the
+// area code, the day, month and year of the date, and the serial number
+func splitIDNumber(number string) (string, [3]int, string, error) {
+	var date [3]int
+
+	if len(number) != 16 || !isDigits(number) {
+		return "", date, "", fmt.Errorf("should be 16 digits")
+	}
+
+	area := number[:6]
+	if _, ok := idProvinces[area[:2]]; !ok {
+		return "", date, "", fmt.Errorf("unknown province code %s", area[:2])
+	}
+
+	if area[2:4] == "00" || area[4:] == "00" {
+		return "", date, "", fmt.Errorf("invalid regency or district code")
+	}
+
+	for i := 0; i < 3; i++ {
+		date[i] = int(number[6+i*2]-'0')*10 + int(number[7+i*2]-'0')
+	}
+
+	serial := number[12:]
+	if serial == "0000" {
+		return "", date, "", fmt.Errorf("invalid serial number")
+	}
+
+	return area, date, serial, nil
+}
+
+// idDate build the date of a two digits year, years after the year of now
+// belong to the previous century
+func idDate(day, month, year int, now time.Time) (time.Time, bool) {
+	century := now.Year() / 100 * 100
+	if century+year > now.Year() {
+		century -= 100
+	}
+
+	t := time.Date(century+year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Day() != day || int(t.Month()) != month {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// isNIK check the provided string is a valid NIK or not
+func isNIK(str string, now time.Time) bool {
+	_, err := parseNIK(str, now)
+	return err == nil
+}
+
+// isKK check the provided string is a valid Kartu Keluarga number, which shares the
+// layout of a NIK with the issuance date in place of the birth date
+func isKK(str string, now time.Time) bool {
+	_, date, _, err := splitIDNumber(str)
+	if err != nil {
+		return false
+	}
+
+	_, ok := idDate(date[0], date[1], date[2], now)
+	return ok
+}
+
+// isNPWP check the provided string is a valid NPWP: the 15 digits format
+// XX.XXX.XXX.X-XXX.XXX where the 9th digit is the Luhn check digit of the
+// first 8, or the 16 digits format being either a NIK or a 0 followed by
+// the 15 digits format
+func isNPWP(str string, now time.Time) bool {
+	str = strings.NewReplacer(".", "", "-", "", " ", "").Replace(str)
+	if !isDigits(str) {
+		return false
+	}
+
+	switch len(str) {
+	case 15:
+		return isLuhn(str[:9])
+	case 16:
+		if str[0] == '0' {
+			return isLuhn(str[1:10])
+		}
+		return isNIK(str, now)
+	}
+
+	return false
+}
+
+// isIndonesiaPostalCode check the provided string is a 5 digits postal code
+func isIndonesiaPostalCode(str string) bool {
+	return len(str) == 5 && isDigits(str) && str[0] != '0'
+}
+
+// indonesiaMobileNumber return the national number, starting with 8, of an
+// indonesian mobile number written with a +62, 62 or 0 prefix
+func indonesiaMobileNumber(str string) (string, bool) {
+	str = strings.NewReplacer(" ", "", "-", "").Replace(str)
+	if !regexPhoneNumberID.MatchString(str) {
+		return "", false
+	}
+
+	switch {
+	case strings.HasPrefix(str, "+62"):
+		str = str[3:]
+	case strings.HasPrefix(str, "62"):
+		str = str[2:]
+	default:
+		str = str[1:]
+	}
+
+	if _, ok := idOperators[str[:3]]; !ok {
+		return "", false
+	}
+
+	return str, true
+}
+
+// ValidNIK check the value is a NIK, the century of the birth year is taken
+// from the clock of the validator
+func ValidNIK(ctx *ruleContext, v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be valid NIK`
+
+	s := ToString(v)
+
+	if !isNIK(s, ctx.validator.Clock()) {
+		return fmt.Errorf(msg, key)
+	}
+
+	return nil
+}
+
+func ValidNPWP(ctx *ruleContext, v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be valid NPWP`
+
+	s := ToString(v)
+
+	if !isNPWP(s, ctx.validator.Clock()) {
+		return fmt.Errorf(msg, key)
+	}
+
+	return nil
+}
+
+// ValidKK check the value is a Kartu Keluarga number, the century of the issuance
+// year is taken from the clock of the validator
+func ValidKK(ctx *ruleContext, v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be valid Kartu Keluarga number`
+
+	s := ToString(v)
+
+	if !isKK(s, ctx.validator.Clock()) {
+		return fmt.Errorf(msg, key)
+	}
+
+	return nil
+}
+
+func ValidIndonesianPostalCode(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be valid postal code`
+
+	s := ToString(v)
+
+	if !isIndonesiaPostalCode(s) {
+		return fmt.Errorf(msg, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,259 @@
+// Package validator
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	jsonSchemas   = map[string]interface{}{}
+	jsonSchemasMu sync.RWMutex
+)
+
+// json_schema validates the document with the rules themselves, it is registered
+// at init to avoid an initialization cycle on contextRules
+func init() {
+	contextRules["json_schema"] = ValidJSONSchema
+}
+
+// jsonOptions are the parameters of the json rule
+type jsonOptions struct {
+	kind     json.Delim // '{' for object, '[' for array, 0 for any value
+	maxDepth int
+	maxSize  int
+}
+
+// jsonRuleOptions parse the parameters of the json rule: object or array,
+// max_depth=N and max_size=N in bytes, e.g. json:object,max_depth=5,max_size=65536
+func jsonRuleOptions(rule string) (jsonOptions, bool) {
+	var opts jsonOptions
+
+	for _, param := range ruleParams(rule) {
+		name, value := param, ""
+		if i := strings.Index(param, "="); i >= 0 {
+			name, value = param[:i], param[i+1:]
+		}
+
+		switch name {
+		case "object":
+			opts.kind = '{'
+		case "array":
+			opts.kind = '['
+		case "max_depth", "max_size":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return opts, false
+			}
+			if name == "max_depth" {
+				opts.maxDepth = n
+			} else {
+				opts.maxSize = n
+			}
+		default:
+			return opts, false
+		}
+	}
+
+	return opts, true
+}
+
+// jsonDocument check the string is a single JSON value and return the delimiter
+// opening its top level value, 0 for a scalar, and its nesting depth
+func jsonDocument(str string) (json.Delim, int, bool) {
+	if !json.Valid([]byte(str)) {
+		return 0, 0, false
+	}
+
+	dec := json.NewDecoder(strings.NewReader(str))
+
+	var kind json.Delim
+	depth, maxDepth := 0, 0
+	for first := true; ; first = false {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, 0, false
+		}
+
+		d, ok := tok.(json.Delim)
+		if !ok {
+			continue
+		}
+
+		if first {
+			kind = d
+		}
+
+		switch d {
+		case '{', '[':
+			depth++
+			if depth > maxDepth {
+				maxDepth = depth
+			}
+		default:
+			depth--
+		}
+	}
+
+	return kind, maxDepth, true
+}
+
+// ValidJSON check the value is a JSON document, the optional parameters restrict
+// the top level value to an object or an array and limit its depth and size,
+// e.g. json:object,max_depth=5,max_size=65536
+func ValidJSON(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	opts, ok := jsonRuleOptions(rule)
+	if !ok {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	s := ToString(v)
+
+	// the size is checked first so an oversized document is never parsed
+	if opts.maxSize > 0 && len(s) > opts.maxSize {
+		return fmt.Errorf(`The %s field should be JSON of at most %d bytes`, key, opts.maxSize)
+	}
+
+	kind, depth, ok := jsonDocument(s)
+	if !ok {
+		return fmt.Errorf(`The %s field should be valid JSON`, key)
+	}
+
+	switch {
+	case opts.kind == '{' && kind != '{':
+		return fmt.Errorf(`The %s field should be JSON object`, key)
+	case opts.kind == '[' && kind != '[':
+		return fmt.Errorf(`The %s field should be JSON array`, key)
+	case opts.maxDepth > 0 && depth > opts.maxDepth:
+		return fmt.Errorf(`The %s field should be JSON nested at most %d levels`, key, opts.maxDepth)
+	}
+
+	return nil
+}
+
+// AddJSONSchema register a schema usable by the json_schema rule, either a struct,
+// or a pointer to struct, whose rule tags validate the decoded document, or a
+// map[string]string of rules keyed by the dot separated path of the document fields
+func AddJSONSchema(name string, schema interface{}) error {
+	if _, ok := schema.(map[string]string); !ok {
+		t := reflect.TypeOf(schema)
+		if t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if t == nil || t.Kind() != reflect.Struct {
+			return fmt.Errorf("validator: json schema %s should be a struct or a map[string]string, got %T", name, schema)
+		}
+		schema = t
+	}
+
+	jsonSchemasMu.Lock()
+	defer jsonSchemasMu.Unlock()
+
+	if _, ok := jsonSchemas[name]; ok {
+		return fmt.Errorf("validator: %s is already defined in json schemas", name)
+	}
+
+	jsonSchemas[name] = schema
+	return nil
+}
+
+// jsonPath resolve the dot separated path in the decoded document
+func jsonPath(doc map[string]interface{}, path string) (interface{}, bool) {
+	var cur interface{} = doc
+	for _, name := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		if cur, ok = m[name]; !ok {
+			return nil, false
+		}
+	}
+
+	return cur, true
+}
+
+// ValidJSONSchema check the value is a JSON object which is valid against the schema
+// registered by AddJSONSchema, e.g. json_schema:webhook_v2. The errors of the document
+// are reported under the field name, like payload.event
+func ValidJSONSchema(ctx *ruleContext, v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	jsonSchemasMu.RLock()
+	schema, ok := jsonSchemas[ruleParam(rule)]
+	jsonSchemasMu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	msg := `The %s field should be valid JSON object`
+
+	s := []byte(ToString(v))
+	errBag := url.Values{}
+
+	switch schema := schema.(type) {
+	case reflect.Type:
+		doc := reflect.New(schema)
+		if err := json.Unmarshal(s, doc.Interface()); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) && typeErr.Field != "" {
+				field := key + "." + typeErr.Field
+				errBag.Add(field, fmt.Sprintf(`The %s field should be a valid %s`, field, typeErr.Type))
+				return fieldErrors(errBag)
+			}
+			return fmt.Errorf(msg, key)
+		}
+
+		errBag = validateStruct(ctx.validator, ctx.walker, doc.Elem(), key)
+	case map[string]string:
+		var doc map[string]interface{}
+		if err := json.Unmarshal(s, &doc); err != nil || doc == nil {
+			return fmt.Errorf(msg, key)
+		}
+
+		docCtx := &ruleContext{
+			validator: ctx.validator,
+			walker:    ctx.walker,
+			lookup: func(name string) (interface{}, bool) {
+				return jsonPath(doc, name)
+			},
+		}
+
+		paths := make([]string, 0, len(schema))
+		for path := range schema {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			value, _ := jsonPath(doc, path)
+			validate(docCtx, value, key+"."+path, splitRules(schema[path]), errBag)
+		}
+	}
+
+	if len(errBag) > 0 {
+		return fieldErrors(errBag)
+	}
+
+	return nil
+}
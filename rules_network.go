@@ -0,0 +1,243 @@
+// Package validator
+package validator
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// nonPublicPrefixes are the special purpose ranges which are not globally
+// reachable beside the private, loopback and link local ones. Ref: RFC 6890
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+// parseAddr parse an IPv4 or IPv6 address, IPv4-mapped IPv6 addresses are unmapped
+func parseAddr(str string) (netip.Addr, bool) {
+	addr, err := netip.ParseAddr(str)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+
+	return addr.Unmap(), true
+}
+
+// isPrivateAddr check the address is private (RFC 1918, RFC 4193)
+func isPrivateAddr(addr netip.Addr) bool {
+	return addr.IsPrivate()
+}
+
+// isPublicAddr check the address is a globally reachable unicast address
+func isPublicAddr(addr netip.Addr) bool {
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isCIDR check the provided string is a network in CIDR notation, version 4 or 6 if
+// given, e.g. 10.0.0.0/8
+func isCIDR(str string, version int) bool {
+	p, err := netip.ParsePrefix(str)
+	if err != nil {
+		return false
+	}
+
+	switch version {
+	case 4:
+		return p.Addr().Is4()
+	case 6:
+		return p.Addr().Is6()
+	}
+
+	return true
+}
+
+// isPort check the provided string is a port number between 1 and 65535
+func isPort(str string) bool {
+	p, err := strconv.ParseUint(str, 10, 16)
+	return err == nil && p > 0
+}
+
+// isHostname check the provided string is a host name as defined by RFC 1123:
+// dot separated labels of letters, digits and hyphens, not starting or ending
+// with a hyphen, up to 63 characters each and 253 in total
+func isHostname(str string) bool {
+	if str == "" || len(str) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(str, ".") {
+		if !isHostLabel(label) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isHostLabel(label string) bool {
+	if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isFQDN check the provided string is a fully qualified domain name: a host name
+// with at least two labels, an optional trailing dot and a non numeric top level domain
+func isFQDN(str string) bool {
+	str = strings.TrimSuffix(str, ".")
+	if !isHostname(str) {
+		return false
+	}
+
+	labels := strings.Split(str, ".")
+	if len(labels) < 2 {
+		return false
+	}
+
+	return !isDigits(labels[len(labels)-1])
+}
+
+// isHostPort check the provided string is a host name or IP address followed
+// by a port, IPv6 addresses are enclosed in square brackets, e.g. [::1]:8080
+func isHostPort(str string) bool {
+	host, port, err := net.SplitHostPort(str)
+	if err != nil || !isPort(port) {
+		return false
+	}
+
+	if strings.Contains(str, "[") {
+		addr, err := netip.ParseAddr(host)
+		return err == nil && addr.Is6()
+	}
+
+	if _, ok := parseAddr(host); ok {
+		return true
+	}
+
+	return isHostname(host)
+}
+
+func ValidCIDR(v interface{}, key, rule string, isRequired bool) error {
+	return validNetwork(v, key, isRequired, `The %s field should be valid CIDR notation`, func(s string) bool {
+		return isCIDR(s, 0)
+	})
+}
+
+func ValidCIDRV4(v interface{}, key, rule string, isRequired bool) error {
+	return validNetwork(v, key, isRequired, `The %s field should be valid IPV4 CIDR notation`, func(s string) bool {
+		return isCIDR(s, 4)
+	})
+}
+
+func ValidCIDRV6(v interface{}, key, rule string, isRequired bool) error {
+	return validNetwork(v, key, isRequired, `The %s field should be valid IPV6 CIDR notation`, func(s string) bool {
+		return isCIDR(s, 6)
+	})
+}
+
+func ValidIPPrivate(v interface{}, key, rule string, isRequired bool) error {
+	return validNetwork(v, key, isRequired, `The %s field should be private IP Address`, func(s string) bool {
+		addr, ok := parseAddr(s)
+		return ok && isPrivateAddr(addr)
+	})
+}
+
+func ValidIPPublic(v interface{}, key, rule string, isRequired bool) error {
+	return validNetwork(v, key, isRequired, `The %s field should be public IP Address`, func(s string) bool {
+		addr, ok := parseAddr(s)
+		return ok && isPublicAddr(addr)
+	})
+}
+
+func ValidPort(v interface{}, key, rule string, isRequired bool) error {
+	return validNetwork(v, key, isRequired, `The %s field should be valid port number`, isPort)
+}
+
+func ValidHostname(v interface{}, key, rule string, isRequired bool) error {
+	return validNetwork(v, key, isRequired, `The %s field should be valid hostname`, isHostname)
+}
+
+func ValidFQDN(v interface{}, key, rule string, isRequired bool) error {
+	return validNetwork(v, key, isRequired, `The %s field should be valid fully qualified domain name`, isFQDN)
+}
+
+func ValidHostPort(v interface{}, key, rule string, isRequired bool) error {
+	return validNetwork(v, key, isRequired, `The %s field should be valid host and port`, isHostPort)
+}
+
+// validNetwork run the check on the string value of a network rule
+func validNetwork(v interface{}, key string, isRequired bool, msg string, check func(string) bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	if !check(ToString(v)) {
+		return fmt.Errorf(msg, key)
+	}
+
+	return nil
+}
+
+// ValidIPIn check the IP address belongs to one of the networks given as
+// parameters, e.g. ip_in:10.0.0.0/8,192.168.0.0/16
+func ValidIPIn(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	params := ruleParams(rule)
+	if len(params) == 0 {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	prefixes := make([]netip.Prefix, 0, len(params))
+	for _, param := range params {
+		p, err := netip.ParsePrefix(strings.TrimSpace(param))
+		if err != nil {
+			return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+		}
+		prefixes = append(prefixes, p.Masked())
+	}
+
+	addr, ok := parseAddr(ToString(v))
+	if !ok {
+		return fmt.Errorf(`The %s field should be valid IP Address`, key)
+	}
+
+	for _, p := range prefixes {
+		if p.Contains(addr) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(`The %s field should be IP Address in %s`, key, strings.Join(params, ", "))
+}
@@ -0,0 +1,328 @@
+// Package validator
+package validator
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// PasswordPolicy is the policy enforced by the password rule
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters
+	MinLength int
+	// Upper, Lower, Digit and Symbol are the required character classes
+	Upper  bool
+	Lower  bool
+	Digit  bool
+	Symbol bool
+	// MaxRepeat is the maximum number of consecutive identical characters, 0 disables the check
+	MaxRepeat int
+	// MinEntropy is the minimum estimated entropy in bits, 0 disables the check
+	MinEntropy float64
+	// NotFields are the sibling fields the password must not contain
+	NotFields []string
+	// NotCommon rejects the passwords of the common passwords list
+	NotCommon bool
+}
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var (
+	commonPasswords     map[string]bool
+	commonPasswordsOnce sync.Once
+
+	passwordPoliciesMu sync.RWMutex
+	passwordPolicies   = map[string]PasswordPolicy{
+		"default": {
+			MinLength:  8,
+			Upper:      true,
+			Lower:      true,
+			Digit:      true,
+			MaxRepeat:  3,
+			MinEntropy: 40,
+			NotFields:  []string{"email", "username"},
+			NotCommon:  true,
+		},
+	}
+)
+
+// keyboardRows are the sequences a password walking on the keyboard follows
+var keyboardRows = []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// AddPasswordPolicy register a named policy usable as first parameter of the
+// password rule, e.g. password:admin
+func AddPasswordPolicy(name string, policy PasswordPolicy) error {
+	passwordPoliciesMu.Lock()
+	defer passwordPoliciesMu.Unlock()
+
+	if _, ok := passwordPolicies[name]; ok {
+		return fmt.Errorf("validator: %s is already defined in password policies", name)
+	}
+
+	passwordPolicies[name] = policy
+	return nil
+}
+
+// isCommonPassword check the password is in the embedded common passwords list
+func isCommonPassword(pw string) bool {
+	commonPasswordsOnce.Do(func() {
+		commonPasswords = map[string]bool{}
+		sc := bufio.NewScanner(strings.NewReader(commonPasswordsFile))
+		for sc.Scan() {
+			line := strings.TrimSpace(sc.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			commonPasswords[strings.ToLower(line)] = true
+		}
+	})
+
+	return commonPasswords[strings.ToLower(pw)]
+}
+
+// passwordPolicy build the policy of the rule: an optional registered policy name
+// followed by overrides like min=12, symbol, max_repeat=2, entropy=50, fields=email;phone
+func passwordPolicy(rule string) (PasswordPolicy, error) {
+	params := ruleParams(rule)
+
+	passwordPoliciesMu.RLock()
+	policy := passwordPolicies["default"]
+	if len(params) > 0 {
+		if p, ok := passwordPolicies[params[0]]; ok {
+			policy = p
+			params = params[1:]
+		}
+	}
+	passwordPoliciesMu.RUnlock()
+
+	for _, param := range params {
+		name, value := param, ""
+		if i := strings.Index(param, "="); i >= 0 {
+			name, value = param[:i], param[i+1:]
+		}
+
+		var err error
+		switch name {
+		case "min":
+			policy.MinLength, err = strconv.Atoi(value)
+		case "upper":
+			policy.Upper = true
+		case "lower":
+			policy.Lower = true
+		case "digit":
+			policy.Digit = true
+		case "symbol":
+			policy.Symbol = true
+		case "max_repeat":
+			policy.MaxRepeat, err = strconv.Atoi(value)
+		case "entropy":
+			policy.MinEntropy, err = strconv.ParseFloat(value, 64)
+		case "fields":
+			policy.NotFields = strings.Split(value, ";")
+		default:
+			err = fmt.Errorf("unknown password policy %s", name)
+		}
+
+		if err != nil {
+			return policy, err
+		}
+	}
+
+	return policy, nil
+}
+
+// passwordEntropy estimate the entropy in bits of the password: each character is worth
+// the size of the character pool it draws from, except the characters repeating the
+// previous one or following it in the alphabet or on the keyboard which are worth one bit.
+// A common password is only worth the size of the common passwords list
+func passwordEntropy(pw string) float64 {
+	if isCommonPassword(pw) {
+		return math.Log2(float64(len(commonPasswords)))
+	}
+
+	pool := 0
+	var lower, upper, digit, symbol, other bool
+	for _, r := range pw {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.present {
+			pool += class.size
+		}
+	}
+
+	if pool == 0 {
+		return 0
+	}
+
+	bits := math.Log2(float64(pool))
+	entropy := 0.0
+	prev := rune(-1)
+	for _, r := range strings.ToLower(pw) {
+		if prev >= 0 && isPredictable(prev, r) {
+			entropy++
+		} else {
+			entropy += bits
+		}
+		prev = r
+	}
+
+	return entropy
+}
+
+// isPredictable check the character repeats or follows the previous one
+func isPredictable(prev, r rune) bool {
+	if r == prev || r == prev+1 || r == prev-1 {
+		return true
+	}
+
+	for _, row := range keyboardRows {
+		i := strings.IndexRune(row, prev)
+		if i < 0 {
+			continue
+		}
+
+		if (i+1 < len(row) && rune(row[i+1]) == r) || (i > 0 && rune(row[i-1]) == r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// maxRepeat return the longest run of identical consecutive characters
+func maxRepeat(pw string) int {
+	max, run := 0, 0
+	prev := rune(-1)
+	for _, r := range pw {
+		if r == prev {
+			run++
+		} else {
+			run = 1
+		}
+
+		if run > max {
+			max = run
+		}
+		prev = r
+	}
+
+	return max
+}
+
+// passwordFailures return the reasons the password does not comply with the policy
+func passwordFailures(ctx *ruleContext, pw string, policy PasswordPolicy) []string {
+	var reasons []string
+
+	if n, _ := lengthOf(pw, false); n < policy.MinLength {
+		reasons = append(reasons, fmt.Sprintf("be at least %d characters", policy.MinLength))
+	}
+
+	classes := []struct {
+		required bool
+		check    func(rune) bool
+		reason   string
+	}{
+		{policy.Upper, unicode.IsUpper, "contain an uppercase letter"},
+		{policy.Lower, unicode.IsLower, "contain a lowercase letter"},
+		{policy.Digit, unicode.IsDigit, "contain a digit"},
+		{policy.Symbol, func(r rune) bool {
+			return unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r)
+		}, "contain a symbol"},
+	}
+
+	for _, class := range classes {
+		if class.required && strings.IndexFunc(pw, class.check) < 0 {
+			reasons = append(reasons, class.reason)
+		}
+	}
+
+	if policy.MaxRepeat > 0 && maxRepeat(pw) > policy.MaxRepeat {
+		reasons = append(reasons, fmt.Sprintf("not repeat a character more than %d times", policy.MaxRepeat))
+	}
+
+	if policy.NotCommon && isCommonPassword(pw) {
+		reasons = append(reasons, "not be a common password")
+	} else if policy.MinEntropy > 0 && passwordEntropy(pw) < policy.MinEntropy {
+		reasons = append(reasons, "be less predictable")
+	}
+
+	lower := strings.ToLower(pw)
+	for _, name := range policy.NotFields {
+		other, ok := ctx.lookup(name)
+		if !ok {
+			continue
+		}
+
+		for _, part := range passwordFieldParts(ToString(indirect(other))) {
+			if strings.Contains(lower, part) {
+				reasons = append(reasons, fmt.Sprintf("not contain the %s", name))
+				break
+			}
+		}
+	}
+
+	return reasons
+}
+
+// passwordFieldParts return the lower cased value of a sibling field, and the local part
+// when it is an email, skipping the values too short to be meaningful
+func passwordFieldParts(value string) []string {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	parts := []string{value}
+	if i := strings.LastIndex(value, "@"); i > 0 {
+		parts = append(parts, value[:i])
+	}
+
+	var out []string
+	for _, p := range parts {
+		if len(p) >= 3 {
+			out = append(out, p)
+		}
+	}
+
+	return out
+}
+
+// ValidPassword check the password complies with the policy of the rule,
+// the error lists every requirement the password misses
+func ValidPassword(ctx *ruleContext, v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	policy, err := passwordPolicy(rule)
+	if err != nil {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	reasons := passwordFailures(ctx, ToString(v), policy)
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(`The %s field should %s`, key, strings.Join(reasons, ", "))
+}
@@ -0,0 +1,209 @@
+// Package validator
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// phoneMetadata describes the numbering plan of a country
+type phoneMetadata struct {
+	code           string   // country calling code
+	trunk          string   // national trunk prefix dialed before the national number
+	lengths        []int    // lengths of the national significant number
+	leadingDigits  []string // leading digits of the national significant numbers in use
+	mobilePrefixes []string // leading digits of the mobile national numbers
+}
+
+// phoneRegions is the offline numbering plan table used by the phone rules,
+// keyed by ISO 3166-1 alpha-2 region code
+var phoneRegions = map[string]phoneMetadata{
+	"ID": {code: "62", trunk: "0", lengths: []int{8, 9, 10, 11, 12},
+		leadingDigits: []string{"2", "3", "4", "5", "6", "7", "8", "9"}, mobilePrefixes: []string{"8"}},
+	"MY": {code: "60", trunk: "0", lengths: []int{8, 9, 10},
+		leadingDigits: []string{"1", "3", "4", "5", "6", "7", "8", "9"}, mobilePrefixes: []string{"1"}},
+	"SG": {code: "65", trunk: "", lengths: []int{8},
+		leadingDigits: []string{"3", "6", "8", "9"}, mobilePrefixes: []string{"8", "9"}},
+	"PH": {code: "63", trunk: "0", lengths: []int{8, 9, 10},
+		leadingDigits: []string{"2", "3", "4", "5", "6", "7", "8", "9"}, mobilePrefixes: []string{"9"}},
+	"TH": {code: "66", trunk: "0", lengths: []int{8, 9},
+		leadingDigits: []string{"2", "3", "4", "5", "6", "7", "8", "9"}, mobilePrefixes: []string{"6", "8", "9"}},
+	"VN": {code: "84", trunk: "0", lengths: []int{9, 10},
+		leadingDigits: []string{"2", "3", "5", "7", "8", "9"}, mobilePrefixes: []string{"3", "5", "7", "8", "9"}},
+}
+
+// phoneNumber is a number split into its region and national significant number
+type phoneNumber struct {
+	region   string
+	national string
+}
+
+// e164 format the number as +<country code><national number>
+func (p phoneNumber) e164() string {
+	return "+" + phoneRegions[p.region].code + p.national
+}
+
+// isMobile check the national number starts with a mobile prefix of its region
+func (p phoneNumber) isMobile() bool {
+	return hasAnyPrefix(p.national, phoneRegions[p.region].mobilePrefixes)
+}
+
+// isNationalNumber check the national significant number fits the numbering plan
+// by its length and leading digits
+func (m phoneMetadata) isNationalNumber(national string) bool {
+	if !hasAnyPrefix(national, m.leadingDigits) {
+		return false
+	}
+
+	for _, l := range m.lengths {
+		if len(national) == l {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePhone parse an international number (+ or 00 prefix) or a national number
+// and return the regions it is valid in, an empty regions list accepts every known
+// region. A national number may fit the numbering plans of several regions
+func parsePhone(number string, regions []string) []phoneNumber {
+	number = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "").Replace(number)
+
+	if len(regions) == 0 {
+		for region := range phoneRegions {
+			regions = append(regions, region)
+		}
+		sort.Strings(regions)
+	}
+
+	international := false
+	switch {
+	case strings.HasPrefix(number, "+"):
+		number, international = number[1:], true
+	case strings.HasPrefix(number, "00"):
+		number, international = number[2:], true
+	}
+
+	if !isDigits(number) {
+		return nil
+	}
+
+	var numbers []phoneNumber
+	for _, region := range regions {
+		region = strings.ToUpper(region)
+		meta, ok := phoneRegions[region]
+		if !ok {
+			continue
+		}
+
+		national := number
+		if international {
+			if !strings.HasPrefix(national, meta.code) {
+				continue
+			}
+			national = national[len(meta.code):]
+		} else if meta.trunk != "" {
+			if !strings.HasPrefix(national, meta.trunk) {
+				continue
+			}
+			national = national[len(meta.trunk):]
+		}
+
+		if meta.isNationalNumber(national) {
+			numbers = append(numbers, phoneNumber{region: region, national: national})
+		}
+	}
+
+	return numbers
+}
+
+// NormalizePhone rewrite a valid phone number of one of the regions to the E.164 format,
+// e.g. NormalizePhone("0812-3456-7890", "ID") returns +6281234567890. A national number
+// valid in more than one of the regions is ambiguous and is not rewritten
+func NormalizePhone(number string, regions ...string) (string, bool) {
+	numbers := parsePhone(number, regions)
+	if len(numbers) != 1 {
+		return "", false
+	}
+
+	return numbers[0].e164(), true
+}
+
+// isE164 check the provided string is a phone number in E.164 format or not
+func isE164(str string) bool {
+	return regexE164.MatchString(str)
+}
+
+// ValidPhone check the value is a phone number of one of the regions given as parameters,
+// or of any known region. The "mobile" parameter only accepts mobile numbers,
+// e.g. phone:ID,MY,SG,mobile
+func ValidPhone(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be valid phone number`
+
+	var regions []string
+	mobile := false
+	for _, param := range ruleParams(rule) {
+		if param == "mobile" {
+			mobile = true
+			continue
+		}
+
+		if _, ok := phoneRegions[strings.ToUpper(param)]; !ok {
+			return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+		}
+		regions = append(regions, param)
+	}
+
+	numbers := parsePhone(ToString(v), regions)
+	if len(numbers) == 0 {
+		return fmt.Errorf(msg, key)
+	}
+
+	if !mobile {
+		return nil
+	}
+
+	for _, p := range numbers {
+		if p.isMobile() {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(`The %s field should be valid mobile phone number`, key)
+}
+
+func ValidE164(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be phone number in E.164 format`
+
+	s := ToString(v)
+
+	if !isE164(s) {
+		return fmt.Errorf(msg, key)
+	}
+
+	return nil
+}
+
+// filterE164 rewrite the phone number to E.164 when it is valid in exactly one of
+// the comma separated regions of the parameter, e.g. `filter:"e164:ID,MY"`
+func filterE164(value, param string) string {
+	var regions []string
+	if param != "" {
+		regions = strings.Split(param, ",")
+	}
+
+	if n, ok := NormalizePhone(value, regions...); ok {
+		return n
+	}
+
+	return value
+}
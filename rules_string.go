@@ -0,0 +1,179 @@
+// Package validator
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// isInFold check if the niddle exist in the haystack, ignoring the case
+func isInFold(haystack []string, niddle string) bool {
+	for _, h := range haystack {
+		if strings.EqualFold(h, niddle) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPrintableASCII check the input only contains printable ASCII characters
+func isPrintableASCII(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if str[i] < 0x20 || str[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// isMultibyte check the input contains at least one multibyte character
+func isMultibyte(str string) bool {
+	return !isASCII(str) && utf8.ValidString(str)
+}
+
+// hasAnyPrefix check the input starts with one of the prefixes
+func hasAnyPrefix(str string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(str, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAnySuffix check the input ends with one of the suffixes
+func hasAnySuffix(str string, suffixes []string) bool {
+	for _, p := range suffixes {
+		if strings.HasSuffix(str, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny check the input contains one of the substrings
+func containsAny(str string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(str, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// validString run the check on the string value and the parameters of the rule,
+// requireParams reject the rule when it has no parameter
+func validString(v interface{}, key, rule string, isRequired, requireParams bool, msg string, check func(s, param string) bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	param := ruleParam(rule)
+	if requireParams && param == "" {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	if !check(ToString(v), param) {
+		if requireParams {
+			return fmt.Errorf(msg, key, param)
+		}
+		return fmt.Errorf(msg, key)
+	}
+
+	return nil
+}
+
+func ValidNotIn(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, true, `The %s field should not be one of: %s`, func(s, param string) bool {
+		return !isIn(ruleParams(rule), s)
+	})
+}
+
+// ValidInFold is the case-insensitive variant of in
+func ValidInFold(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, true, `The %s field should be contain in: %s`, func(s, param string) bool {
+		return isInFold(ruleParams(rule), s)
+	})
+}
+
+// ValidNotInFold is the case-insensitive variant of not_in
+func ValidNotInFold(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, true, `The %s field should not be one of: %s`, func(s, param string) bool {
+		return !isInFold(ruleParams(rule), s)
+	})
+}
+
+// ValidContains check the value contains the parameter, commas included
+func ValidContains(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, true, `The %s field should contain %s`, strings.Contains)
+}
+
+// ValidContainsAny check the value contains one of the comma separated parameters
+func ValidContainsAny(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, true, `The %s field should contain one of: %s`, func(s, param string) bool {
+		return containsAny(s, ruleParams(rule))
+	})
+}
+
+// ValidExcludes check the value does not contain the parameter, commas included
+func ValidExcludes(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, true, `The %s field should not contain %s`, func(s, param string) bool {
+		return !strings.Contains(s, param)
+	})
+}
+
+// ValidExcludesRune check the value contains none of the characters of the parameter
+func ValidExcludesRune(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, true, `The %s field should not contain any of the characters: %s`, func(s, param string) bool {
+		return !strings.ContainsAny(s, param)
+	})
+}
+
+func ValidStartsWith(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, true, `The %s field should start with one of: %s`, func(s, param string) bool {
+		return hasAnyPrefix(s, ruleParams(rule))
+	})
+}
+
+func ValidEndsWith(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, true, `The %s field should end with one of: %s`, func(s, param string) bool {
+		return hasAnySuffix(s, ruleParams(rule))
+	})
+}
+
+func ValidDoesntStartWith(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, true, `The %s field should not start with one of: %s`, func(s, param string) bool {
+		return !hasAnyPrefix(s, ruleParams(rule))
+	})
+}
+
+func ValidLowercase(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, false, `The %s field should be lowercase`, func(s, param string) bool {
+		return s == strings.ToLower(s)
+	})
+}
+
+func ValidUppercase(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, false, `The %s field should be uppercase`, func(s, param string) bool {
+		return s == strings.ToUpper(s)
+	})
+}
+
+func ValidASCII(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, false, `The %s field should contain ASCII characters only`, func(s, param string) bool {
+		return isASCII(s)
+	})
+}
+
+func ValidPrintableASCII(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, false, `The %s field should contain printable ASCII characters only`, func(s, param string) bool {
+		return isPrintableASCII(s)
+	})
+}
+
+func ValidMultibyte(v interface{}, key, rule string, isRequired bool) error {
+	return validString(v, key, rule, isRequired, false, `The %s field should contain multibyte characters`, func(s, param string) bool {
+		return isMultibyte(s)
+	})
+}
@@ -0,0 +1,154 @@
+// Package validator
+package validator
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// parseURL parse an absolute URL having a scheme and a host
+func parseURL(str string) (*url.URL, bool) {
+	if strings.IndexFunc(str, unicode.IsSpace) >= 0 {
+		return nil, false
+	}
+
+	u, err := url.Parse(str)
+	if err != nil || u.Scheme == "" || u.Opaque != "" || u.Hostname() == "" {
+		return nil, false
+	}
+
+	if port := u.Port(); port != "" && !isPort(port) {
+		return nil, false
+	}
+
+	return u, true
+}
+
+// isURI check the provided string is an absolute URI with a scheme, like
+// mailto:user@example.com or urn:isbn:0451450523
+func isURI(str string) bool {
+	if strings.IndexFunc(str, unicode.IsSpace) >= 0 {
+		return false
+	}
+
+	u, err := url.Parse(str)
+	if err != nil || u.Scheme == "" {
+		return false
+	}
+
+	return u.Opaque != "" || u.Host != "" || u.Path != ""
+}
+
+// matchHost check the host matches the pattern, *.example.com matches
+// the subdomains of example.com but not example.com itself
+func matchHost(host, pattern string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+	if strings.HasPrefix(pattern, "*.") {
+		return strings.HasSuffix(host, pattern[1:]) && len(host) > len(pattern)-1
+	}
+
+	return host == pattern
+}
+
+// isInternalHost check the host is not safe to be requested from the server:
+// an IP address which is not public, localhost, or a numeric host which some
+// resolvers read as an IP address like 2130706433 or 0x7f.1
+func isInternalHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	if addr, ok := parseAddr(host); ok {
+		return !isPublicAddr(addr)
+	}
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	labels := strings.Split(host, ".")
+	tld := labels[len(labels)-1]
+
+	return isDigits(tld) || strings.HasPrefix(tld, "0x")
+}
+
+// ValidURLHostIn check the host of the URL is one of the parameters,
+// e.g. url_host_in:example.com,*.example.org
+func ValidURLHostIn(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	hosts := ruleParams(rule)
+	if len(hosts) == 0 {
+		return fmt.Errorf(`The %s field invalid rule format %s`, key, rule)
+	}
+
+	u, ok := parseURL(ToString(v))
+	if !ok {
+		return fmt.Errorf(`The %s field should be valid URL`, key)
+	}
+
+	for _, h := range hosts {
+		if matchHost(u.Hostname(), h) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(`The %s field should be URL with host in: %s`, key, strings.Join(hosts, ", "))
+}
+
+func ValidURLNoCredentials(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	u, ok := parseURL(ToString(v))
+	if !ok {
+		return fmt.Errorf(`The %s field should be valid URL`, key)
+	}
+
+	if u.User != nil {
+		return fmt.Errorf(`The %s field should be URL without credentials`, key)
+	}
+
+	return nil
+}
+
+// ValidURLNoPrivateIP reject the URLs targeting a private, loopback or otherwise
+// internal host, to defend against SSRF on URLs requested by the server like webhooks.
+// The host name is not resolved, the address must still be checked when connecting
+func ValidURLNoPrivateIP(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	u, ok := parseURL(ToString(v))
+	if !ok {
+		return fmt.Errorf(`The %s field should be valid URL`, key)
+	}
+
+	if isInternalHost(u.Hostname()) {
+		return fmt.Errorf(`The %s field should be URL with public host`, key)
+	}
+
+	return nil
+}
+
+func ValidURI(v interface{}, key, rule string, isRequired bool) error {
+	if isEmpty(v) && !isRequired {
+		return nil
+	}
+
+	msg := `The %s field should be valid URI`
+
+	s := ToString(v)
+
+	if !isURI(s) {
+		return fmt.Errorf(msg, key)
+	}
+
+	return nil
+}
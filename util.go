@@ -5,10 +5,15 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
+	"net/netip"
 	"net/url"
 	"reflect"
-	"regexp"
 	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // DumpToString cast all data type to json string
@@ -66,7 +71,7 @@ func mergeKeys(left, right url.Values) url.Values {
 // Match regular expression validation
 func Match(value interface{}, key, format, msg string) error {
 
-	rgx, e := regexp.Compile(format)
+	rgx, e := compileRegex(format)
 
 	if e != nil {
 		return fmt.Errorf("%s invalid rule regular expression %s: %s", key, format, e.Error())
@@ -199,24 +204,97 @@ func ToInt64(i interface{}) (int64, error) {
 	}
 }
 
-// isAlpha check the input is letters (a-z,A-Z) or not
-func isAlpha(str string) bool {
-	return regexAlpha.MatchString(str)
-}
+// toNumber convert the numeric kinds and numeric strings into an exact rational,
+// floats go through their shortest decimal representation so 0.1 stays 0.1
+func toNumber(i interface{}) (*big.Rat, bool) {
+	v := reflect.ValueOf(indirect(i))
 
-// isAlphaDash check the input is letters, number with dash and underscore
-func isAlphaDash(str string) bool {
-	return regexAlphaDash.MatchString(str)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(v.Uint())), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		return new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, v.Type().Bits()))
+	case reflect.String:
+		if !isNumeric(v.String()) {
+			return nil, false
+		}
+		return new(big.Rat).SetString(v.String())
+	}
+
+	return nil, false
 }
 
-// isAlphaSpace check the input is letters, number with dash and underscore
-func isAlphaSpace(str string) bool {
-	return regexAlphaSpace.MatchString(str)
+// numberOrLength return the value as a number, or its length when it is not numeric
+func numberOrLength(i interface{}) (*big.Rat, bool) {
+	if n, ok := toNumber(i); ok {
+		return n, true
+	}
+
+	n, _ := lengthOf(i, false)
+
+	return new(big.Rat).SetInt64(int64(n)), false
 }
 
-// isAlphaNumeric check the input is alpha numeric or not
-func isAlphaNumeric(str string) bool {
-	return regexAlphaNumeric.MatchString(str)
+// lengthOf return the number of characters of a string, or the number of
+// elements of a slice, array or map. Other kinds are measured by their string
+// representation and reported as not being a string or a collection
+func lengthOf(i interface{}, grapheme bool) (int, bool) {
+	v := reflect.ValueOf(indirect(i))
+
+	switch v.Kind() {
+	case reflect.String:
+		if grapheme {
+			return graphemeCount(v.String()), true
+		}
+		return utf8.RuneCountInString(v.String()), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), true
+	}
+
+	return utf8.RuneCountInString(ToString(i)), false
+}
+
+// graphemeCount approximate the number of user-perceived characters: combining marks,
+// variation selectors, skin tone modifiers and zero width joined sequences extend the
+// previous character, and a pair of regional indicators forms a single flag
+func graphemeCount(str string) int {
+	n := 0
+	joined := false
+	flag := false
+	for _, r := range str {
+		switch {
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc),
+			r >= 0xFE00 && r <= 0xFE0F,
+			r >= 0x1F3FB && r <= 0x1F3FF,
+			r >= 0xE0020 && r <= 0xE007F:
+			continue
+		case r == 0x200D:
+			joined = true
+			continue
+		case joined:
+			joined = false
+			continue
+		case r >= 0x1F1E6 && r <= 0x1F1FF:
+			if flag {
+				flag = false
+				continue
+			}
+			flag = true
+			n++
+			continue
+		}
+
+		flag = false
+		n++
+	}
+
+	return n
 }
 
 // isBoolean check the input contains boolean type values
@@ -232,9 +310,15 @@ func isBoolean(str string) bool {
 }
 
 //isCreditCard check the provided card number is a valid
-//  Visa, MasterCard, American Express, Diners Club, Discover or JCB card
+//  Visa, MasterCard, American Express, Diners Club, Discover, JCB or UnionPay card
+//  passing the Luhn checksum, spaces and hyphens are ignored
 func isCreditCard(card string) bool {
-	return regexCreditCard.MatchString(card)
+	card = stripSeparators(card)
+	if !isLuhn(card) {
+		return false
+	}
+	_, ok := cardBrandOf(card)
+	return ok
 }
 
 // isCoordinate is a valid Coordinate or not
@@ -247,21 +331,6 @@ func isCSSColor(str string) bool {
 	return regexCSSColor.MatchString(str)
 }
 
-// isDate check the date string is valid or not
-func isDate(date string) bool {
-	return regexDate.MatchString(date)
-}
-
-// isDateDDMMYY check the date string is valid or not
-func isDateDDMMYY(date string) bool {
-	return regexDateDDMMYY.MatchString(date)
-}
-
-// isEmail check a email is valid or not
-func isEmail(email string) bool {
-	return regexEmail.MatchString(email)
-}
-
 // isFloat check the input string is a float or not
 func isFloat(str string) bool {
 	return regexFloat.MatchString(str)
@@ -277,15 +346,6 @@ func isIn(haystack []string, niddle string) bool {
 	return false
 }
 
-// isJSON check wheather the input string is a valid json or not
-func isJSON(str string) bool {
-	var data interface{}
-	if err := json.Unmarshal([]byte(str), &data); err != nil {
-		return false
-	}
-	return true
-}
-
 // isNumeric check the provided input string is numeric or not
 func isNumeric(str string) bool {
 	return regexNumeric.MatchString(str)
@@ -308,56 +368,114 @@ func isLongitude(str string) bool {
 
 // isIP check the provided input string is a valid IP address or not
 func isIP(str string) bool {
-	return regexIP.MatchString(str)
+	_, ok := parseAddr(str)
+	return ok
 }
 
 // isIPV4 check the provided input string is a valid IP address version 4 or not
 // Ref: https://en.wikipedia.org/wiki/IPv4
 func isIPV4(str string) bool {
-	return regexIPV4.MatchString(str)
+	addr, err := netip.ParseAddr(str)
+	return err == nil && addr.Is4()
 }
 
 // isIPV6 check the provided input string is a valid IP address version 6 or not
 // Ref: https://en.wikipedia.org/wiki/IPv6
 func isIPV6(str string) bool {
-	return regexIPV6.MatchString(str)
+	addr, err := netip.ParseAddr(str)
+	return err == nil && addr.Is6()
 }
 
-// isMatchedRegex match the regular expression string provided in first argument
-// with second argument which is also a string
-func isMatchedRegex(rxStr, str string) bool {
-	rx := regexp.MustCompile(rxStr)
-	return rx.MatchString(str)
+// isDigits check the input contains digits only
+func isDigits(str string) bool {
+	return regexDigitsOnly.MatchString(str)
 }
 
-// isURL check a URL is valid or not
-func isURL(url string) bool {
-	return regexURL.MatchString(url)
+// isSemver check the input is a valid semantic version
+func isSemver(str string) bool {
+	return regexSemver.MatchString(str)
 }
 
-// isUUID check the provided string is valid UUID or not
-func isUUID(str string) bool {
-	return regexUUID.MatchString(str)
+// parseUUID check the provided string is a UUID in the canonical 8-4-4-4-12 hex
+// form, in any case, and return its version. The version is 0 when the variant
+// is not the RFC 4122 one, like the nil UUID
+func parseUUID(str string) (int, bool) {
+	if len(str) != 36 {
+		return 0, false
+	}
+
+	for i := 0; i < len(str); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if str[i] != '-' {
+				return 0, false
+			}
+		default:
+			if !isHexDigit(str[i]) {
+				return 0, false
+			}
+		}
+	}
+
+	switch str[19] {
+	case '8', '9', 'a', 'b', 'A', 'B':
+		return int(hexValue(str[14])), true
+	}
+
+	return 0, true
+}
+
+// isHexDigit check the byte is an hexadecimal digit
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// hexValue return the value of an hexadecimal digit
+func hexValue(c byte) byte {
+	switch {
+	case c >= 'a':
+		return c - 'a' + 10
+	case c >= 'A':
+		return c - 'A' + 10
+	}
+	return c - '0'
+}
+
+// isUUIDVersion check the provided string is valid UUID of the version or not
+func isUUIDVersion(str string, version int) bool {
+	v, ok := parseUUID(str)
+	return ok && v == version
 }
 
 // isUUID3 check the provided string is valid UUID version 3 or not
 func isUUID3(str string) bool {
-	return regexUUID3.MatchString(str)
+	return isUUIDVersion(str, 3)
 }
 
 // isUUID4 check the provided string is valid UUID version 4 or not
 func isUUID4(str string) bool {
-	return regexUUID4.MatchString(str)
+	return isUUIDVersion(str, 4)
 }
 
 // isUUID5 check the provided string is valid UUID version 5 or not
 func isUUID5(str string) bool {
-	return regexUUID5.MatchString(str)
+	return isUUIDVersion(str, 5)
 }
 
-// isIMEI check the provided string is valid IMEI or not
+// isIMEI check the provided string is valid IMEI passing the Luhn checksum,
+// or a 16 digits IMEISV which has no check digit
 func isIMEI(str string) bool {
-	return regexIMEI.MatchString(str)
+	str = strings.NewReplacer(" ", "", "-", "", "/", "").Replace(str)
+	if !isDigits(str) {
+		return false
+	}
+	switch len(str) {
+	case 15:
+		return isLuhn(str)
+	case 16:
+		return true
+	}
+	return false
 }
 
 // isHexColor check the provided string is valid hexa color or not
@@ -367,15 +485,22 @@ func isHexColor(str string) bool {
 
 // isISBN10 check the provided string is valid ISBN10 or not
 func isISBN10(str string) bool {
-	return regexISBN10.MatchString(str)
+	return isISBN10Checksum(stripSeparators(str))
 }
 
 // isISBN13 check the provided string is valid ISBN13 or not
 func isISBN13(str string) bool {
-	return regexISBN13.MatchString(str)
+	return isISBN13Checksum(stripSeparators(str))
+}
+
+// isIBAN check the provided string is valid IBAN or not
+func isIBAN(str string) bool {
+	return isIBANChecksum(strings.ToUpper(stripSeparators(str)))
 }
 
-// isIndonesiaPhoneNumber check the provided string is valid indonesian phone number or not
+// isIndonesiaPhoneNumber check the provided string is valid indonesian mobile phone number
+// with a known operator prefix or not
 func isIndonesiaPhoneNumber(str string) bool {
-	return regexPhoneNumberID.MatchString(str)
+	_, ok := indonesiaMobileNumber(str)
+	return ok
 }
@@ -3,9 +3,11 @@ package validator
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"reflect"
 	"strings"
+	"time"
 )
 
 const (
@@ -16,8 +18,19 @@ const (
 type Option func(*Validator)
 
 type Validator struct {
-	TagField string
-	TagRule  string
+	TagField   string
+	TagRule    string
+	TagFilter  string
+	TagDefault string
+	Clock      func() time.Time
+	Resolver   Resolver
+	KeyStyle   KeyStyle
+
+	NamingStrategy NamingStrategy
+	FieldNameFunc  FieldNameFunc
+
+	UnexportedFields bool
+	MaxDepth         int
 }
 
 // OptionTagField option tag field
@@ -34,7 +47,81 @@ func OptionTagRule(tag string) Option {
 	}
 }
 
-func validate(value interface{}, fieldName string, tags []string, errBag url.Values) error {
+// OptionTagFilter option tag filter
+func OptionTagFilter(tag string) Option {
+	return func(v *Validator) {
+		v.TagFilter = tag
+	}
+}
+
+// OptionTagDefault option tag default value
+func OptionTagDefault(tag string) Option {
+	return func(v *Validator) {
+		v.TagDefault = tag
+	}
+}
+
+// OptionClock option clock used by the date rules to resolve now, today, etc,
+// and by id_nik and id_kk to resolve the century of a two digits year
+func OptionClock(clock func() time.Time) Option {
+	return func(v *Validator) {
+		v.Clock = clock
+	}
+}
+
+// OptionResolver option resolver used by the email_mx rule, tests can provide a fake
+func OptionResolver(r Resolver) Option {
+	return func(v *Validator) {
+		v.Resolver = r
+	}
+}
+
+// OptionKeyStyle option key style of the collection elements in the error bag
+func OptionKeyStyle(style KeyStyle) Option {
+	return func(v *Validator) {
+		v.KeyStyle = style
+	}
+}
+
+// OptionNamingStrategy option naming strategy of the fields without tag field name
+func OptionNamingStrategy(strategy NamingStrategy) Option {
+	return func(v *Validator) {
+		v.NamingStrategy = strategy
+	}
+}
+
+// OptionFieldNameFunc option custom naming of the fields in the error bag
+func OptionFieldNameFunc(fn FieldNameFunc) Option {
+	return func(v *Validator) {
+		v.FieldNameFunc = fn
+	}
+}
+
+// OptionUnexportedFields option validate the unexported fields having a rule tag,
+// they are skipped by default
+func OptionUnexportedFields(enabled bool) Option {
+	return func(v *Validator) {
+		v.UnexportedFields = enabled
+	}
+}
+
+// OptionMaxDepth option maximum nesting depth of the structs and collections walked,
+// the values nested deeper are reported under the _error key
+func OptionMaxDepth(depth int) Option {
+	return func(v *Validator) {
+		v.MaxDepth = depth
+	}
+}
+
+// fieldErrors is returned by the rules validating a nested document, like
+// json_schema, its keys are merged in the error bag in place of the field
+type fieldErrors url.Values
+
+func (e fieldErrors) Error() string {
+	return Errors(e).Error()
+}
+
+func validate(ctx *ruleContext, value interface{}, fieldName string, tags []string, errBag url.Values) error {
 
 	isRequired := false
 
@@ -48,16 +135,24 @@ func validate(value interface{}, fieldName string, tags []string, errBag url.Val
 			isRequired = true
 		}
 
-		f, ok := rules[fn]
-		if !ok {
+		var err error
+		if f, ok := rules[fn]; ok {
+			err = f(value, fieldName, tags[i], isRequired)
+		} else if f, ok := contextRules[fn]; ok {
+			err = f(ctx, value, fieldName, tags[i], isRequired)
+		} else {
 			continue
 		}
 
-		err := f(value, fieldName, tags[i], isRequired)
 		if err == nil {
 			continue
 		}
 
+		if fe, ok := err.(fieldErrors); ok {
+			mergeKeys(errBag, url.Values(fe))
+			continue
+		}
+
 		errBag.Add(fieldName, err.Error())
 
 	}
@@ -66,47 +161,173 @@ func validate(value interface{}, fieldName string, tags []string, errBag url.Val
 
 }
 
-func validateStruct(v reflect.Value, parentField, tagField, tagRule string) url.Values {
+// fieldKey returns the error bag key of a struct field, prefixed by its parent
+func (vl *Validator) fieldKey(parentField string, fi reflect.StructField) string {
+	tf := vl.fieldName(fi)
+
+	if parentField != "" {
+		tf = fmt.Sprintf("%s.%s", parentField, tf)
+	}
+
+	return tf
+}
+
+// fieldName returns the name of a struct field: the name given by the FieldNameFunc
+// option, else the name of the field tag without its options, else the Go name
+// renamed by the naming strategy
+func (vl *Validator) fieldName(fi reflect.StructField) string {
+	if vl.FieldNameFunc != nil {
+		if name := vl.FieldNameFunc(fi); name != "" {
+			return name
+		}
+	}
+
+	if name := tagFieldName(fi, vl.TagField); name != "" {
+		return name
+	}
+
+	return vl.NamingStrategy.rename(fi.Name)
+}
+
+func validateStruct(vl *Validator, w *walker, v reflect.Value, parentField string) url.Values {
 	errBag := url.Values{}
-	t := v.Type()
-	for i := 0; i < v.NumField(); i++ {
-		// gets us a StructField
-		fi := t.Field(i)
 
-		tf := fi.Tag.Get(tagField)
-		tr := fi.Tag.Get(tagRule)
+	if w == nil {
+		w = newWalker(vl)
+	}
+
+	if !w.enter(v, parentField, errBag) {
+		return errBag
+	}
+	defer w.leave(v)
+
+	ctx := &ruleContext{validator: vl, walker: w, lookup: structLookup(vl, v)}
+	for _, f := range structFields(v.Type(), vl.TagField) {
+		tr := f.Tag.Get(vl.TagRule)
 
 		if tr == "" || tr == "-" {
 			continue
 		}
 
-		tags := strings.Split(tr, "|")
+		// unexported fields are only validated on opt in
+		if f.PkgPath != "" && !vl.UnexportedFields {
+			continue
+		}
 
-		if parentField != "" {
-			tf = fmt.Sprintf("%s.%s", parentField, tf)
+		// the fields promoted through a nil embedded pointer are skipped,
+		// a required on the embedded field reports it
+		fv, ok := fieldByIndex(v, f.Index)
+		if !ok {
+			continue
 		}
 
-		validate(v.Field(i).Interface(), tf, tags, errBag)
+		fv, ok = readable(fv)
+		if !ok {
+			continue
+		}
 
-		switch v.Field(i).Kind() {
-		case reflect.Struct:
-			eb := validateStruct(v.Field(i), tf, tagField, tagRule)
-			mergeKeys(errBag, eb)
-		case reflect.Slice:
-		case reflect.Ptr:
-			ptrRef := reflect.Indirect(v.Field(i))
-			switch ptrRef.Kind() {
-			case reflect.Struct:
-				eb := validateStruct(ptrRef, tf, tagField, tagRule)
-				mergeKeys(errBag, eb)
-			}
+		tf := vl.fieldKey(parentField, f.StructField)
+
+		// the fields of a promoted struct are validated with its parent fields,
+		// only its own rules run on it
+		if f.promoted {
+			validate(ctx, fv.Interface(), tf, splitRules(tr), errBag)
+			continue
 		}
 
+		validateValue(vl, ctx, fv, tf, splitRules(tr), errBag)
 	}
 
 	return errBag
 }
 
+// validateValue run the rules on the value then descend into the nested structs,
+// and into the elements of a collection with the rules following dive
+func validateValue(vl *Validator, ctx *ruleContext, v reflect.Value, key string, tags []string, errBag url.Values) {
+	tags, elemTags, dive := splitDive(tags)
+
+	validate(ctx, v.Interface(), key, tags, errBag)
+
+	// descend through the pointers and the interfaces to the value they hold
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			v = reflect.Value{}
+			break
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == timeType {
+			break
+		}
+		mergeKeys(errBag, validateStruct(vl, ctx.walker, v, key))
+	case reflect.Slice, reflect.Array, reflect.Map:
+		validateElements(vl, ctx, v, key, elemTags, dive, errBag)
+	case reflect.Invalid:
+		// nil pointer or interface, required already reported it
+	default:
+		if dive {
+			errBag.Add(key, fmt.Sprintf(`The %s field invalid rule format %s`, key, ruleDive))
+		}
+	}
+}
+
+// prepareStruct fill the zero fields with their default value then apply
+// the filters, an empty tag name disable the step
+func prepareStruct(vl *Validator, w *walker, v reflect.Value, parentField, tagDefault, tagFilter string, errBag url.Values) {
+	if w == nil {
+		w = newWalker(vl)
+	}
+
+	if !w.enter(v, parentField, errBag) {
+		return
+	}
+	defer w.leave(v)
+
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		fi := t.Field(i)
+		fv := v.Field(i)
+
+		if !fv.CanSet() {
+			continue
+		}
+
+		tf := vl.fieldKey(parentField, fi)
+		if isPromoted(fi, vl.TagField) {
+			tf = parentField
+		}
+
+		if tagDefault != "" {
+			if td, ok := fi.Tag.Lookup(tagDefault); ok && td != "-" {
+				if err := applyDefault(fv, td); err != nil {
+					errBag.Add(tf, fmt.Sprintf(`The %s field has invalid default value, expected %s`, tf, err.Error()))
+				}
+			}
+		}
+
+		if tagFilter != "" {
+			if tr := fi.Tag.Get(tagFilter); tr != "" && tr != "-" {
+				applyFilters(fv, strings.Split(tr, "|"))
+			}
+		}
+
+		// only the nested structs having a default or a filter tag are walked
+		if !needsPrepare(fv.Type(), tagDefault, tagFilter) {
+			continue
+		}
+
+		switch {
+		case fv.Kind() == reflect.Struct && fv.Type() != timeType:
+			prepareStruct(vl, w, fv, tf, tagDefault, tagFilter, errBag)
+		case fv.Kind() == reflect.Ptr && !fv.IsNil() && fv.Elem().Kind() == reflect.Struct && fv.Elem().Type() != timeType:
+			prepareStruct(vl, w, fv.Elem(), tf, tagDefault, tagFilter, errBag)
+		}
+	}
+}
+
 func New(options ...Option) *Validator {
 	x := &Validator{}
 
@@ -122,13 +343,34 @@ func New(options ...Option) *Validator {
 		x.TagField = defaultTagField
 	}
 
+	if x.TagFilter == "" {
+		x.TagFilter = defaultTagFilter
+	}
+
+	if x.TagDefault == "" {
+		x.TagDefault = defaultTagDefault
+	}
+
+	if x.Clock == nil {
+		x.Clock = time.Now
+	}
+
+	if x.Resolver == nil {
+		x.Resolver = net.DefaultResolver
+	}
+
+	if x.MaxDepth <= 0 {
+		x.MaxDepth = defaultMaxDepth
+	}
+
 	return x
 }
 
 func (vl *Validator) ValidateStruct(input interface{}) url.Values {
-	var errBag url.Values
+	errBag := url.Values{}
 
 	val := reflect.ValueOf(input)
+	isPtr := val.Kind() == reflect.Ptr
 	if val.Kind() == reflect.Interface || val.Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
@@ -138,5 +380,25 @@ func (vl *Validator) ValidateStruct(input interface{}) url.Values {
 		return errBag
 	}
 
-	return validateStruct(val, "", vl.TagField, vl.TagRule)
+	prepare := needsPrepare(val.Type(), vl.TagDefault, vl.TagFilter)
+
+	// both passes share the walker so the maximum depth is reported once
+	w := newWalker(vl)
+
+	// defaults and filters mutate the fields in place, they need an addressable struct
+	if !isPtr {
+		if prepare {
+			errBag.Set(`_error`, fmt.Sprintf("validator: default and filter require a pointer to struct, got %s", val.Type()))
+			return errBag
+		}
+
+		// a copy is addressable, which the unexported fields need to be read
+		cp := reflect.New(val.Type()).Elem()
+		cp.Set(val)
+		val = cp
+	} else if prepare {
+		prepareStruct(vl, w, val, "", vl.TagDefault, vl.TagFilter, errBag)
+	}
+
+	return mergeKeys(errBag, validateStruct(vl, w, val, ""))
 }
@@ -0,0 +1,224 @@
+package validator
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+type nestedAddress struct {
+	City string `json:"city" valid:"required"`
+}
+
+type nestedProfile struct {
+	Name    string         `json:"name" valid:"required"`
+	Address *nestedAddress `json:"address" valid:"required"`
+}
+
+type unexportedProfile struct {
+	Name   string `json:"name" valid:"required"`
+	secret string `valid:"required|min:3"`
+}
+
+type interfaceProfile struct {
+	Value interface{} `json:"value" valid:"required"`
+}
+
+// assertKeys check the error bag has exactly the given keys
+func assertKeys(t *testing.T, errBag url.Values, keys ...string) {
+	t.Helper()
+
+	if len(errBag) != len(keys) {
+		t.Fatalf("got errors %v, want keys %v", errBag, keys)
+	}
+
+	for _, k := range keys {
+		if _, ok := errBag[k]; !ok {
+			t.Fatalf("got errors %v, want key %s", errBag, k)
+		}
+	}
+}
+
+func TestValidateStructNilNestedPointer(t *testing.T) {
+	vl := New()
+
+	p := nestedProfile{Name: "gopher"}
+	assertKeys(t, vl.ValidateStruct(&p), "address")
+
+	p.Address = &nestedAddress{}
+	assertKeys(t, vl.ValidateStruct(&p), "address.city")
+
+	p.Address.City = "Jakarta"
+	assertKeys(t, vl.ValidateStruct(&p))
+}
+
+func TestValidateStructUnexportedFields(t *testing.T) {
+	p := unexportedProfile{Name: "gopher", secret: "x"}
+
+	tests := []struct {
+		name    string
+		options []Option
+		keys    []string
+	}{
+		{name: "skipped by default", keys: nil},
+		{name: "validated when enabled", options: []Option{OptionUnexportedFields(true)}, keys: []string{"secret"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertKeys(t, New(tt.options...).ValidateStruct(&p), tt.keys...)
+		})
+	}
+}
+
+func TestValidateStructByValueReadsUnexportedFields(t *testing.T) {
+	vl := New(OptionUnexportedFields(true))
+
+	assertKeys(t, vl.ValidateStruct(unexportedProfile{Name: "gopher", secret: "x"}), "secret")
+	assertKeys(t, vl.ValidateStruct(unexportedProfile{Name: "gopher", secret: "xyz"}))
+}
+
+func TestValidateStructInterfaceFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		keys  []string
+	}{
+		// a zero struct is empty for required, as a struct field is
+		{name: "struct", value: nestedAddress{}, keys: []string{"value", "value.city"}},
+		{name: "valid struct", value: nestedAddress{City: "Jakarta"}},
+		{name: "pointer to struct", value: &nestedAddress{}, keys: []string{"value.city"}},
+		{name: "valid pointer to struct", value: &nestedAddress{City: "Jakarta"}},
+		{name: "nil", value: nil, keys: []string{"value"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := interfaceProfile{Value: tt.value}
+			assertKeys(t, New().ValidateStruct(&p), tt.keys...)
+		})
+	}
+}
+
+// ruleCase is a value expected to pass or fail a rule
+type ruleCase struct {
+	value interface{}
+	rule  string
+	valid bool
+}
+
+// assertRules check each value passes or fails its rule
+func assertRules(t *testing.T, vl *Validator, cases []ruleCase) {
+	t.Helper()
+
+	for _, c := range cases {
+		err := vl.Var(c.value, c.rule)
+		if c.valid && err != nil {
+			t.Errorf("%v with %s: got error %v, want valid", c.value, c.rule, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("%v with %s: got valid, want error", c.value, c.rule)
+		}
+	}
+}
+
+func TestChecksumRules(t *testing.T) {
+	assertRules(t, New(), []ruleCase{
+		{"4111111111111111", "credit_card", true},
+		{"4111-1111-1111-1111", "credit_card", true},
+		{"5500000000000004", "credit_card", true},
+		{"378282246310005", "credit_card", true},
+		{"4111111111111112", "credit_card", false},
+		{"1234567812345678", "credit_card", false},
+
+		{"0306406152", "isbn10", true},
+		{"0-8044-2957-X", "isbn10", true},
+		{"0306406153", "isbn10", false},
+		{"9780306406157", "isbn13", true},
+		{"978-0-306-40615-7", "isbn13", true},
+		{"9780306406158", "isbn13", false},
+
+		{"GB82WEST12345698765432", "iban", true},
+		{"GB82 WEST 1234 5698 7654 32", "iban", true},
+		{"DE89370400440532013000", "iban", true},
+		{"GB82WEST12345698765433", "iban", false},
+		{"GB00WEST12345698765432", "iban", false},
+
+		{"490154203237518", "imei", true},
+		{"49-015420-323751-8", "imei", true},
+		{"490154203237519", "imei", false},
+	})
+}
+
+func TestIndonesiaRules(t *testing.T) {
+	vl := New(OptionClock(func() time.Time {
+		return time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	}))
+
+	assertRules(t, vl, []ruleCase{
+		{"3171012902000001", "id_nik", true},
+		{"3171016902000001", "id_nik", true}, // women have 40 added to the day
+		{"9971012902000001", "id_nik", false},
+		{"3100012902000001", "id_nik", false},
+		{"3171013102000001", "id_nik", false},
+		{"3171012902000000", "id_nik", false},
+		{"317101290200001", "id_nik", false},
+
+		{"3171010101240001", "id_kk", true},
+		{"3171013002240001", "id_kk", false},
+
+		{"01.234.567.4-012.000", "id_npwp", true},
+		{"01.234.567.5-012.000", "id_npwp", false},
+		{"3171012902000001", "id_npwp", true},
+	})
+
+	// 29 February 2000 is a date, 29 February 1900 is not
+	before := New(OptionClock(func() time.Time {
+		return time.Date(1999, 5, 1, 0, 0, 0, 0, time.UTC)
+	}))
+	assertRules(t, before, []ruleCase{
+		{"3171012902000001", "id_nik", false},
+	})
+}
+
+func TestPhoneRules(t *testing.T) {
+	assertRules(t, New(), []ruleCase{
+		{"+6281234567890", "phone", true},
+		{"0812-3456-7890", "phone:ID", true},
+		{"0812-3456-7890", "phone:ID,mobile", true},
+		{"021-5555-1234", "phone:ID,mobile", false},
+		{"0123456789", "phone:ID", false}, // indonesian numbers never start with 1
+		{"012-345 6789", "phone:MY", true},
+		{"91234567", "phone:SG,mobile", true},
+		{"61234567", "phone:SG", true},
+		{"61234567", "phone:SG,mobile", false},
+		{"+6591234567", "phone:ID", false},
+		{"09171234567", "phone:PH", true},
+		{"+6281234567890", "e164", true},
+		{"081234567890", "e164", false},
+	})
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		number  string
+		regions []string
+		want    string
+		ok      bool
+	}{
+		{number: "0812-3456-7890", regions: []string{"ID"}, want: "+6281234567890", ok: true},
+		{number: "012-345 6789", regions: []string{"ID", "MY"}, want: "+60123456789", ok: true},
+		{number: "0123456789", want: "+60123456789", ok: true},
+		{number: "+65 9123 4567", want: "+6591234567", ok: true},
+		// valid in both regions, a national number is not rewritten when ambiguous
+		{number: "0312345678", regions: []string{"ID", "MY"}},
+		{number: "12345", regions: []string{"ID"}},
+	}
+
+	for _, tt := range tests {
+		got, ok := NormalizePhone(tt.number, tt.regions...)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NormalizePhone(%q, %v) = %q, %v, want %q, %v", tt.number, tt.regions, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Package validator
+package validator
+
+import (
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// varField is the name of the value validated by Var in the error messages
+const varField = `value`
+
+// Errors is the error returned by Var and VarWithValue, the messages are keyed
+// like the error bag of ValidateStruct
+type Errors url.Values
+
+// Error join the messages, sorted by key
+func (e Errors) Error() string {
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var msgs []string
+	for _, k := range keys {
+		msgs = append(msgs, e[k]...)
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Var validate a single value against the rules, written as in the rule tag,
+// e.g. vl.Var(id, "required|uuid:v4"). The value is named "value" in the messages.
+// The error is Errors, or *MaxDepthError when the value is nested deeper than MaxDepth
+func (vl *Validator) Var(value interface{}, rules string) error {
+	return vl.validateVar(value, rules, func(name string) (interface{}, bool) {
+		return nil, false
+	})
+}
+
+// VarWithValue validate a value against rules comparing it to the other value,
+// which is the sibling resolved by any field name given to the rules,
+// e.g. vl.VarWithValue(end, start, "required|after:start")
+func (vl *Validator) VarWithValue(field, other interface{}, rules string) error {
+	return vl.validateVar(field, rules, func(name string) (interface{}, bool) {
+		return other, true
+	})
+}
+
+func (vl *Validator) validateVar(value interface{}, rules string, lookup func(name string) (interface{}, bool)) error {
+	errBag := url.Values{}
+	w := newWalker(vl)
+	ctx := &ruleContext{validator: vl, walker: w, lookup: lookup}
+
+	validateValue(vl, ctx, reflect.ValueOf(&value).Elem(), varField, splitRules(rules), errBag)
+
+	// the walk stopped at the maximum depth, the errors collected are partial
+	if w.err != nil {
+		return w.err
+	}
+
+	if len(errBag) == 0 {
+		return nil
+	}
+
+	return Errors(errBag)
+}
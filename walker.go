@@ -0,0 +1,101 @@
+// Package validator
+package validator
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+)
+
+// defaultMaxDepth is the nesting depth of structs and collections walked by default
+const defaultMaxDepth = 64
+
+// MaxDepthError is reported when a value is nested deeper than the MaxDepth option,
+// ValidateStruct adds its message to the error bag under the _error key while
+// Var and VarWithValue return it, so it can be told apart with errors.As
+type MaxDepthError struct {
+	Field    string
+	MaxDepth int
+}
+
+func (e *MaxDepthError) Error() string {
+	return fmt.Sprintf("validator: %s field exceeds the maximum depth of %d", e.Field, e.MaxDepth)
+}
+
+// visitKey identifies a struct or a map being walked, the type tells apart
+// a struct from its first field sharing its address
+type visitKey struct {
+	ptr uintptr
+	t   reflect.Type
+}
+
+// walker tracks the values being walked from the root, to stop on the cycles
+// of self-referential types and on the values nested too deep
+type walker struct {
+	maxDepth int
+	depth    int
+	visiting map[visitKey]bool
+	types    map[reflect.Type]int // struct types being walked
+	err      *MaxDepthError
+}
+
+func newWalker(vl *Validator) *walker {
+	return &walker{maxDepth: vl.MaxDepth, visiting: map[visitKey]bool{}, types: map[reflect.Type]int{}}
+}
+
+// identity return the key of a value which can be reached again through a cycle
+func identity(v reflect.Value) (visitKey, bool) {
+	switch {
+	case v.Kind() == reflect.Map && !v.IsNil():
+		return visitKey{v.Pointer(), v.Type()}, true
+	case v.Kind() == reflect.Struct && v.CanAddr():
+		return visitKey{v.UnsafeAddr(), v.Type()}, true
+	}
+
+	return visitKey{}, false
+}
+
+// enter a nested value, false when the value is already being walked by one
+// of its parents, or when it is nested deeper than the maximum depth which is
+// then reported once in the error bag
+func (w *walker) enter(v reflect.Value, key string, errBag url.Values) bool {
+	if w.depth >= w.maxDepth {
+		if w.err == nil {
+			w.err = &MaxDepthError{Field: key, MaxDepth: w.maxDepth}
+			errBag.Add(`_error`, w.err.Error())
+		}
+		return false
+	}
+
+	if id, ok := identity(v); ok {
+		if w.visiting[id] {
+			return false
+		}
+		w.visiting[id] = true
+	}
+
+	if v.Kind() == reflect.Struct {
+		w.types[v.Type()]++
+	}
+
+	w.depth++
+	return true
+}
+
+// leave a nested value entered
+func (w *walker) leave(v reflect.Value) {
+	if id, ok := identity(v); ok {
+		delete(w.visiting, id)
+	}
+
+	if v.Kind() == reflect.Struct {
+		w.types[v.Type()]--
+	}
+
+	w.depth--
+}
+
+// walking check a struct of the type is being walked by one of the parents
+func (w *walker) walking(t reflect.Type) bool {
+	return w.types[t] > 0
+}